@@ -0,0 +1,201 @@
+package prob
+
+import (
+  "math"
+  "sort"
+)
+
+// A source of variation in an ANOVA table.
+type ANOVARow struct {
+  Degrees     float64   `json:"degrees"`
+  SumSquares  float64   `json:"sumSquares"`
+  MeanSquare  float64   `json:"meanSquare"`
+}
+
+// The table of a one-way analysis of variance. Total has no mean square, so
+// its MeanSquare is NaN. Result is the F-test of Between against Within.
+type ANOVATable struct {
+  Between   ANOVARow    `json:"between"`
+  Within    ANOVARow    `json:"within"`
+  Total     ANOVARow    `json:"total"`
+  Result    TestResult  `json:"result"`
+}
+
+// Checks there are at least two groups, each with at least min values.
+func validateGroups(groups [][]float64, min int) error {
+  if len(groups) < 2 {
+    return InvalidParamsError{ S: "There must be at least two groups." }
+  }
+  for _, group := range groups {
+    if len(group) < min {
+      if min == 1 {
+        return InvalidParamsError{ S: "Groups must not be empty." }
+      }
+      return InvalidParamsError{ S: "Groups must contain at least two values." }
+    }
+  }
+  return nil
+}
+
+func fResult(statistic, d1, d2 float64) TestResult {
+  return TestResult{
+    Statistic:    statistic,
+    Degrees:      d1,
+    Degrees2:     d2,
+    PValue:       1 - F{ d1, d2 }.Cdf(statistic),
+    Alternative:  Greater,
+    Estimate:     math.NaN(),
+    Lower:        math.NaN(),
+    Upper:        math.NaN(),
+  }
+}
+
+// The one-way analysis of variance that the groups share a mean, assuming
+// they share a variance.
+//
+// See: https://en.wikipedia.org/wiki/One-way_analysis_of_variance
+func OneWayANOVA(groups ...[]float64) (ANOVATable, error) {
+  if err := validateGroups(groups, 1); err != nil {
+    return ANOVATable{}, err
+  }
+  var all RunningStats
+  for _, group := range groups {
+    all.AddAll(group)
+  }
+  if all.Count() <= len(groups) {
+    return ANOVATable{}, InvalidParamsError{ S: "There must be more values than groups." }
+  }
+  between, within := 0.0, 0.0
+  for _, group := range groups {
+    var stats RunningStats
+    stats.AddAll(group)
+    diff := stats.Mean() - all.Mean()
+    between += stats.Weight() * diff * diff
+    within += stats.Weight() * stats.Variance()
+  }
+  d1, d2 := float64(len(groups) - 1), all.Weight() - float64(len(groups))
+  table := ANOVATable{
+    Between:  ANOVARow{ d1, between, between / d1 },
+    Within:   ANOVARow{ d2, within, within / d2 },
+    Total:    ANOVARow{ all.Weight() - 1, between + within, math.NaN() },
+  }
+  table.Result = fResult(table.Between.MeanSquare / table.Within.MeanSquare, d1, d2)
+  return table, nil
+}
+
+// Welch's one-way analysis of variance that the groups share a mean, without
+// assuming equal variances. The denominator degrees of freedom are generally
+// not whole numbers.
+//
+// Ref: Welch (1951), On the comparison of several mean values.
+func WelchANOVA(groups ...[]float64) (TestResult, error) {
+  if err := validateGroups(groups, 2); err != nil {
+    return TestResult{}, err
+  }
+  k := float64(len(groups))
+  weights := make([]float64, len(groups))
+  means := make([]float64, len(groups))
+  sizes := make([]float64, len(groups))
+  totalWeight, weightedMean := 0.0, 0.0
+  for i, group := range groups {
+    mean, variance := sampleMoments(group)
+    if !(variance > 0) {
+      return TestResult{}, InvalidParamsError{ S: "Groups must not have zero variance." }
+    }
+    sizes[i] = float64(len(group))
+    weights[i] = sizes[i] / variance
+    means[i] = mean
+    totalWeight += weights[i]
+    weightedMean += weights[i] * mean
+  }
+  weightedMean /= totalWeight
+  between, lambda := 0.0, 0.0
+  for i := range groups {
+    diff := means[i] - weightedMean
+    between += weights[i] * diff * diff
+    share := 1 - (weights[i] / totalWeight)
+    lambda += share * share / (sizes[i] - 1)
+  }
+  statistic := (between / (k - 1)) / (1 + (2 * (k - 2) * lambda / ((k * k) - 1)))
+  d2 := ((k * k) - 1) / (3 * lambda)
+  return fResult(statistic, k - 1, d2), nil
+}
+
+// The median of values, which are not modified.
+func median(values []float64) float64 {
+  sorted := append([]float64{}, values...)
+  sort.Float64s(sorted)
+  n := len(sorted)
+  if n % 2 == 1 {
+    return sorted[n / 2]
+  }
+  return (sorted[(n / 2) - 1] + sorted[n / 2]) / 2
+}
+
+// The one-way ANOVA of absolute deviations from each group's center.
+func deviationANOVA(groups [][]float64, center func([]float64) float64) (TestResult, error) {
+  if err := validateGroups(groups, 2); err != nil {
+    return TestResult{}, err
+  }
+  deviations := make([][]float64, len(groups))
+  for i, group := range groups {
+    c := center(group)
+    deviations[i] = make([]float64, len(group))
+    for j, x := range group {
+      deviations[i][j] = math.Abs(x - c)
+    }
+  }
+  table, err := OneWayANOVA(deviations...)
+  if err != nil {
+    return TestResult{}, err
+  }
+  return table.Result, nil
+}
+
+// Levene's test that the groups share a variance, from the ANOVA of absolute
+// deviations from the group means.
+//
+// See: https://en.wikipedia.org/wiki/Levene%27s_test
+func LeveneTest(groups ...[]float64) (TestResult, error) {
+  return deviationANOVA(groups, func(group []float64) float64 {
+    mean, _ := sampleMoments(group)
+    return mean
+  })
+}
+
+// The Brown–Forsythe test that the groups share a variance, from the ANOVA
+// of absolute deviations from the group medians. It is more robust than
+// Levene's test to skewed data.
+//
+// See: https://en.wikipedia.org/wiki/Brown%E2%80%93Forsythe_test
+func BrownForsytheTest(groups ...[]float64) (TestResult, error) {
+  return deviationANOVA(groups, median)
+}
+
+// Bartlett's test that the groups share a variance, assuming they are
+// normal. The statistic is compared with a chi-squared distribution with
+// k - 1 degrees of freedom.
+//
+// See: https://en.wikipedia.org/wiki/Bartlett%27s_test
+func BartlettTest(groups ...[]float64) (TestResult, error) {
+  if err := validateGroups(groups, 2); err != nil {
+    return TestResult{}, err
+  }
+  k := float64(len(groups))
+  total, pooled, logSum, reciprocal := 0.0, 0.0, 0.0, 0.0
+  for _, group := range groups {
+    _, variance := sampleMoments(group)
+    if !(variance > 0) {
+      return TestResult{}, InvalidParamsError{ S: "Groups must not have zero variance." }
+    }
+    degrees := float64(len(group) - 1)
+    total += degrees
+    pooled += degrees * variance
+    logSum += degrees * math.Log(variance)
+    reciprocal += 1 / degrees
+  }
+  pooled /= total
+  correction := 1 + ((reciprocal - (1 / total)) / (3 * (k - 1)))
+  statistic := ((total * math.Log(pooled)) - logSum) / correction
+  return chiSquaredResult(statistic, k - 1), nil
+}
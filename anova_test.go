@@ -0,0 +1,88 @@
+package prob
+
+import (
+  "math"
+  "testing"
+)
+
+// Plant weights by treatment, as in R's datasets::PlantGrowth.
+var (
+  plantControl = []float64{ 4.17, 5.58, 5.18, 6.11, 4.50, 4.61, 5.17, 4.53, 5.33, 5.14 }
+  plantTreatment1 = []float64{ 4.81, 4.17, 4.41, 3.59, 5.87, 3.83, 6.03, 4.89, 4.32, 4.69 }
+  plantTreatment2 = []float64{ 6.31, 5.12, 5.54, 5.50, 5.37, 5.29, 4.92, 6.15, 5.80, 5.26 }
+)
+
+// Test against R's aov and oneway.test.
+func Test_ANOVA(t *testing.T) {
+  nan := math.NaN()
+  table, err := OneWayANOVA(plantControl, plantTreatment1, plantTreatment2)
+  if err != nil {
+    t.Fatal(err)
+  }
+  rows := []struct{ name string; got, expected ANOVARow }{
+    { "Between",  table.Between,  ANOVARow{ 2, 3.76634, 1.88317 } },
+    { "Within",   table.Within,   ANOVARow{ 27, 10.49209, 0.3885959259259259 } },
+    { "Total",    table.Total,    ANOVARow{ 29, 14.25843, nan } },
+  }
+  for _, row := range rows {
+    if row.got.Degrees != row.expected.Degrees || !floatsPicoEqual(row.got.SumSquares, row.expected.SumSquares) {
+      t.Fatalf("\n%s:\n  Expected: %v\n  Got: %v\n", row.name, row.expected, row.got)
+    }
+    if !floatsPicoEqual(row.got.MeanSquare, row.expected.MeanSquare) && !checkNaN(row.got.MeanSquare, row.expected.MeanSquare) {
+      t.Fatalf("\n%s:\n  Expected: %v\n  Got: %v\n", row.name, row.expected, row.got)
+    }
+  }
+  if err := checkTestResult(table.Result, nil, TestResult{
+    Statistic: 4.846087862380148, Degrees: 2, Degrees2: 27, PValue: 0.01590995832562281,
+    Lower: nan, Upper: nan,
+  }); err != nil {
+    t.Fatal(err)
+  }
+  result, err := WelchANOVA(plantControl, plantTreatment1, plantTreatment2)
+  if err := checkTestResult(result, err, TestResult{
+    Statistic: 5.180972408113202, Degrees: 2, Degrees2: 17.12841861664413, PValue: 0.017392821490169852,
+    Lower: nan, Upper: nan,
+  }); err != nil {
+    t.Fatal(err)
+  }
+
+  if _, err := OneWayANOVA(plantControl); err == nil {
+    t.Fatal("\nExpected an error for a single group.")
+  }
+  if _, err := OneWayANOVA(plantControl, []float64{}); err == nil {
+    t.Fatal("\nExpected an error for an empty group.")
+  }
+  if _, err := WelchANOVA(plantControl, []float64{ 1, 1, 1 }); err == nil {
+    t.Fatal("\nExpected an error for a group with zero variance.")
+  }
+}
+
+// Test against car::leveneTest and R's bartlett.test.
+func Test_ANOVA_Variances(t *testing.T) {
+  nan := math.NaN()
+  result, err := LeveneTest(plantControl, plantTreatment1, plantTreatment2)
+  if err := checkTestResult(result, err, TestResult{
+    Statistic: 1.2369629544697835, Degrees: 2, Degrees2: 27, PValue: 0.30619492299144635,
+    Lower: nan, Upper: nan,
+  }); err != nil {
+    t.Fatal(err)
+  }
+  result, err = BrownForsytheTest(plantControl, plantTreatment1, plantTreatment2)
+  if err := checkTestResult(result, err, TestResult{
+    Statistic: 1.1191856948703909, Degrees: 2, Degrees2: 27, PValue: 0.3412266241254721,
+    Lower: nan, Upper: nan,
+  }); err != nil {
+    t.Fatal(err)
+  }
+  result, err = BartlettTest(plantControl, plantTreatment1, plantTreatment2)
+  if err := checkTestResult(result, err, TestResult{
+    Statistic: 2.878573787236097, Degrees: 2, Degrees2: nan, PValue: 0.23709677363455772,
+    Lower: nan, Upper: nan,
+  }); err != nil {
+    t.Fatal(err)
+  }
+
+  if _, err := BartlettTest(plantControl, []float64{ 1.0 }); err == nil {
+    t.Fatal("\nExpected an error for a group with one value.")
+  }
+}
@@ -2,6 +2,7 @@ package prob
 
 import (
   "math"
+  "math/rand"
 )
 
 //TheBeta  Distribution is a continuous probability distribution
@@ -21,12 +22,24 @@ func NewBeta(alpha float64, beta float64) (Beta, error) {
   return dist, nil
 }
 
+// The beta distribution with the given mean μ and precision φ = α + β, so
+// that α = μφ and β = (1 - μ)φ.
+func NewBetaMeanPrecision(mean, precision float64) (Beta, error) {
+  if err := checkParam("Beta", "Mean", mean, mean > 0 && mean < 1, "be strictly between zero and one"); err != nil {
+    return Beta{}, err
+  }
+  if err := checkPositive("Beta", "Precision", precision); err != nil {
+    return Beta{}, err
+  }
+  return NewBeta(mean * precision, (1 - mean) * precision)
+}
+
 func (dist Beta) Validate() error {
-  if dist.Alpha <= 0 {
-    return InvalidParamsError{ "Alpha must be greater than zero." }
+  if err := checkPositive("Beta", "Alpha", dist.Alpha); err != nil {
+    return err
   }
-  if dist.Beta <= 0 {
-    return InvalidParamsError{ "Beta must be greater than zero." }
+  if err := checkPositive("Beta", "Beta", dist.Beta); err != nil {
+    return err
   }
   return nil
 }
@@ -70,8 +83,36 @@ func (dist Beta) RelStdDev() float64 {
 }
 
 func (dist Beta) Pdf(x float64) float64 {
-  beta := BetaFn(dist.Alpha, dist.Beta)
-  result := math.Pow(x, dist.Alpha - 1) * math.Pow(1 - x, dist.Beta - 1) / beta
+  return dist.pdf(x, LogBeta(dist.Alpha, dist.Beta))
+}
+
+func (dist Beta) PdfSlice(xs, out []float64) {
+  logBeta := LogBeta(dist.Alpha, dist.Beta)
+  for i, x := range xs {
+    out[i] = dist.pdf(x, logBeta)
+  }
+}
+
+func (dist Beta) LogPdfSlice(xs, out []float64) {
+  logBeta := LogBeta(dist.Alpha, dist.Beta)
+  for i, x := range xs {
+    if x <= 0 || x >= 1 {
+      out[i] = math.Log(dist.pdf(x, logBeta))
+      continue
+    }
+    out[i] = ((dist.Alpha - 1) * math.Log(x)) + ((dist.Beta - 1) * math.Log1p(-x)) - logBeta
+  }
+}
+
+// The density with log B(α, β) already computed.
+func (dist Beta) pdf(x, logBeta float64) float64 {
+  if x < 0 || x > 1 {
+    return 0.0
+  }
+  if x == 0 || x == 1 {
+    return math.Pow(x, dist.Alpha - 1) * math.Pow(1 - x, dist.Beta - 1) / BetaFn(dist.Alpha, dist.Beta)
+  }
+  result := math.Exp(((dist.Alpha - 1) * math.Log(x)) + ((dist.Beta - 1) * math.Log(1 - x)) - logBeta)
   return result
 }
 
@@ -80,10 +121,19 @@ func (dist Beta) Cdf(x float64) float64 {
   return result
 }
 
+func (dist Beta) Quantile(p float64) float64 {
+  result := RegBetaIncInv(dist.Alpha, dist.Beta, p)
+  return result
+}
+
 // Ref: https://github.com/ampl/gsl/blob/master/randist/beta.c
-func (dist Beta) Random() float64 {
-  u1 := Gamma{ Shape: dist.Alpha, Rate: 1.0 }.Random()
-  u2 := Gamma{ Shape: dist.Beta, Rate: 1.0 }.Random()
+func (dist Beta) RandomWith(r *rand.Rand) float64 {
+  u1 := Gamma{ Shape: dist.Alpha, Rate: 1.0 }.RandomWith(r)
+  u2 := Gamma{ Shape: dist.Beta, Rate: 1.0 }.RandomWith(r)
   result := u1 / (u1 + u2)
   return result
 }
+
+func (dist Beta) Random() float64 {
+  return dist.RandomWith(nil)
+}
@@ -50,6 +50,17 @@ func Test_Beta(t *testing.T) {
     t.Fatal(err)
   }
 
+  quantiles := []inOut{
+    inOut{ in: 0.5,   out: 0.3857275681323896 },
+    inOut{ in: 0.9,   out: 0.6795394162781817 },
+  }
+  for _, quantile := range quantiles {
+    out := Beta{2.0, 3.0}.Quantile(quantile.in)
+    if !floatsNanoEqual(out, quantile.out) {
+      t.Fatalf("\nQuantile of %f:\n  Expected: %f\n  Got: %f\n", quantile.in, quantile.out, out)
+    }
+  }
+
   sample := Beta{5.0, 4.0}
   if err := testSamples(sample); err != nil {
     t.Fatal(err)
@@ -60,3 +71,27 @@ func Benchmark_Beta(b *testing.B) {
   dist := Beta{5.0, 4.0}
   runBenchmark(b, dist)
 }
+
+func Test_Beta_Constructors(t *testing.T) {
+  dist, err := NewBetaMeanPrecision(0.25, 8)
+  if err != nil || dist.Alpha != 2 || dist.Beta != 6 || !floatsPicoEqual(dist.Mean(), 0.25) {
+    t.Fatalf("\nMeanPrecision:\n  Expected: %v\n  Got: %v, %v\n", Beta{ 2, 6 }, dist, err)
+  }
+  // The variance is μ(1 - μ) / (1 + φ).
+  if !floatsPicoEqual(dist.Variance(), 0.25 * 0.75 / 9) {
+    t.Fatalf("\nVariance:\n  Expected: %f\n  Got: %f\n", 0.25 * 0.75 / 9, dist.Variance())
+  }
+  for _, invalid := range [][2]float64{ { 0, 8 }, { 1, 8 }, { 0.5, 0 } } {
+    if _, err := NewBetaMeanPrecision(invalid[0], invalid[1]); err == nil {
+      t.Fatalf("\nExpected an error for %v.", invalid)
+    }
+  }
+}
+
+func Benchmark_Beta_Pdf(b *testing.B) {
+  runPdfBenchmark(b, Beta{ 2.5, 3.5 }, 0, 1)
+}
+
+func Benchmark_Beta_PdfSlice(b *testing.B) {
+  runPdfSliceBenchmark(b, Beta{ 2.5, 3.5 }, 0, 1)
+}
@@ -20,21 +20,21 @@ type Binomial struct {
   Prob    float64   `json:"prob"`
 }
 
+// Trials is rounded down to a whole number.
 func NewBinomial(trials float64, prob float64) (Binomial, error) {
-  dist := Binomial{trials, prob}
+  dist := Binomial{math.Floor(trials), prob}
   if err := dist.Validate(); err != nil {
     return dist, err
   }
   return dist, nil
 }
 
-func (dist *Binomial) Validate() error {
-  dist.Trials = math.Floor(dist.Trials)
-  if dist.Trials < 0 {
-    return InvalidParamsError{ "Trials must be greater than zero." }
+func (dist Binomial) Validate() error {
+  if err := checkParam("Binomial", "Trials", dist.Trials, dist.Trials >= 0 && dist.Trials == math.Floor(dist.Trials), "be a whole number not less than zero"); err != nil {
+    return err
   }
-  if dist.Prob < 0 || dist.Prob > 1 {
-    return InvalidParamsError{ "Prob must be between zero and one." }
+  if err := checkProb("Binomial", "Prob", dist.Prob); err != nil {
+    return err
   }
   return nil
 }
@@ -69,51 +69,35 @@ func (dist Binomial) RelStdDev() float64 {
   return result
 }
 
+// Uses Loader's saddle point expansion, which stays accurate for large n.
 func (dist Binomial) Pdf(x float64) float64 {
   if x < 0.0 || x > dist.Trials {
     return 0.0
   }
   x = math.Floor(x)
-  if dist.Prob == 0 {
-    if x == 0 {
-      return 1.0
-    }
-    return 0.0
-  }
-  if dist.Prob == 1 {
-    if x == dist.Trials {
-      return 1.0
-    }
-    return 0.0
-  }
-  cnk := BinomialCoefficient(dist.Trials, x)
-  pows := math.Pow(dist.Prob, x) * math.Pow(1 - dist.Prob, dist.Trials - x)
-  if math.IsInf(cnk, 0) {
-    return 0.0
-  }
-  result := cnk * pows
+  result := binomialRaw(x, dist.Trials, dist.Prob, 1 - dist.Prob)
   return result
 }
 
+// Uses P(X <= k) = I₁₋ₚ(n - k, k + 1).
 func (dist Binomial) Cdf(x float64) float64 {
   if (x < 0.0) {
     return 0.0
   }
-  if (x > dist.Trials) {
+  x = math.Floor(x)
+  if (x >= dist.Trials) {
     return 1.0
   }
-  result := 0.0
-  end := math.Floor(x) + 1
-  for i := 0.0; i < end; i++ {
-    current := BinomialCoefficient(dist.Trials, i)
-    pows := math.Pow(dist.Prob, i) * math.Pow(1 - dist.Prob, dist.Trials - i)
-    result += current * pows
-  }
+  result := RegBetaInc(dist.Trials - x, x + 1, 1 - dist.Prob)
   return result
 }
 
+func (dist Binomial) Quantile(p float64) float64 {
+  return discreteQuantile(dist, p, 0, dist.Trials)
+}
+
 // Ref: https://github.com/ampl/gsl/blob/48fbd40c7c9c24913a68251d23bdbd0637bbda20/randist/binomial_tpe.c
-func (dist Binomial) Random() float64 {
+func (dist Binomial) RandomWith(r *rand.Rand) float64 {
   if dist.Trials == 0 {
     return 0.0
   }
@@ -131,7 +115,7 @@ func (dist Binomial) Random() float64 {
     f0 := math.Pow(q, dist.Trials)
     for {
       f := f0
-      u := rand.Float64()
+      u := randFloat64(r)
       for ix = 0; ix <= binv_cutoff; ix++ {
         if u < f {
           goto Finish
@@ -159,8 +143,8 @@ func (dist Binomial) Random() float64 {
     var varr, accept, u, v float64
 
     TryAgain:
-      u = rand.Float64() * p4
-      v = rand.Float64()
+      u = randFloat64(r) * p4
+      v = randFloat64(r)
       if u <= p1 {
         ix = math.Floor(xm - (p1 * v) + u)
         goto Finish
@@ -205,3 +189,7 @@ func (dist Binomial) Random() float64 {
     }
     return value
 }
+
+func (dist Binomial) Random() float64 {
+  return dist.RandomWith(nil)
+}
@@ -1,8 +1,12 @@
 package prob
 
-import "testing"
+import (
+  "math"
+  "testing"
+)
 
 // Test at http://keisan.casio.com/exec/system/1180573199
+// Large trials are tested against exact rational arithmetic.
 func Test_Binomial(t *testing.T) {
   examples := []distributionTest{
     distributionTest{
@@ -24,6 +28,36 @@ func Test_Binomial(t *testing.T) {
         inOut{ in: 5.0,  out: 0.623046875 },
       },
     },
+    distributionTest{
+      dist:       &Binomial{1e6, 0.3},
+      mean:       300000.0,
+      variance:   210000.0,
+      stdDev:     math.Sqrt(210000),
+      relStdDev:  math.Sqrt(210000) / 300000,
+      skewness:   0.4 / math.Sqrt(210000),
+      kurtosis:   3 - 6e-6 + (1.0 / 210000),
+      pdf: []inOut{
+        inOut{ in: 300500.0, out: 0.00047991816459567587 },
+      },
+      cdf: []inOut{
+        inOut{ in: 299000.0, out: 0.01456821921993674 },
+      },
+    },
+    distributionTest{
+      dist:       &Binomial{1e6, 0.5},
+      mean:       500000.0,
+      variance:   250000.0,
+      stdDev:     500.0,
+      relStdDev:  0.001,
+      skewness:   0.0,
+      kurtosis:   3 - 6e-6 + 4e-6,
+      pdf: []inOut{
+        inOut{ in: 500000.0, out: 0.0007978843613317501 },
+      },
+      cdf: []inOut{
+        inOut{ in: 500000.0, out: 0.50039894218066587 },
+      },
+    },
   }
 
   if err := testValues(examples); err != nil {
@@ -40,3 +74,18 @@ func Benchmark_Binomial(b *testing.B) {
   dist := &Binomial{10.0, 0.5}
   runBenchmark(b, dist)
 }
+
+func Test_Binomial_Constructors(t *testing.T) {
+  dist, err := NewBinomial(10.7, 0.5)
+  if err != nil || dist.Trials != 10 {
+    t.Fatalf("\nNewBinomial:\n  Expected: %v\n  Got: %v, %v\n", Binomial{10, 0.5}, dist, err)
+  }
+  // Validate reports fractional trials without rounding them.
+  fractional := Binomial{10.7, 0.5}
+  if err := fractional.Validate(); err == nil || fractional.Trials != 10.7 {
+    t.Fatalf("\nValidate:\n  Expected: an error for %v\n  Got: %v, %v\n", 10.7, fractional, err)
+  }
+  if samples, err := Sample(dist, 10); err != nil || len(samples) != 10 {
+    t.Fatalf("\nSample:\n  Expected: %d samples\n  Got: %d, %v\n", 10, len(samples), err)
+  }
+}
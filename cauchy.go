@@ -23,8 +23,11 @@ func NewCauchy(location float64, scale float64) (Cauchy, error) {
 }
 
 func (dist Cauchy) Validate() error {
-  if dist.Location <= 0 {
-    return InvalidParamsError{ "Location must be greater than zero." }
+  if err := checkFinite("Cauchy", "Location", dist.Location); err != nil {
+    return err
+  }
+  if err := checkPositive("Cauchy", "Scale", dist.Scale); err != nil {
+    return err
   }
   return nil
 }
@@ -65,11 +68,26 @@ func (dist Cauchy) Cdf(x float64) float64 {
   return result
 }
 
-func (dist Cauchy) Random() float64 {
+func (dist Cauchy) Quantile(p float64) float64 {
+  if p < 0 || p > 1 || math.IsNaN(p) {
+    return math.NaN()
+  }
+  if p == 0 || p == 1 {
+    return math.Inf(int(2 * p) - 1)
+  }
+  result := dist.Location + (dist.Scale * math.Tan(math.Pi * (p - 0.5)))
+  return result
+}
+
+func (dist Cauchy) RandomWith(r *rand.Rand) float64 {
   var u float64
   for u == 0.0 || u == 0.5 {
-      u = rand.Float64()
+      u = randFloat64(r)
   }
   result := dist.Location + (dist.Scale * math.Atan(math.Pi * u))
   return result
 }
+
+func (dist Cauchy) Random() float64 {
+  return dist.RandomWith(nil)
+}
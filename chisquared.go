@@ -2,6 +2,7 @@ package prob
 
 import (
   "math"
+  "math/rand"
 )
 
 //The ChiSquared Distribution is a continuous probability distribution
@@ -21,8 +22,8 @@ func NewChiSquared(degrees float64) (ChiSquared, error) {
 }
 
 func (dist ChiSquared) Validate() error {
-  if dist.Degrees <= 0 {
-    return InvalidParamsError{ "Degrees must be greater than zero." }
+  if err := checkPositive("ChiSquared", "Degrees", dist.Degrees); err != nil {
+    return err
   }
   return nil
 }
@@ -57,6 +58,33 @@ func (dist ChiSquared) RelStdDev() float64 {
 }
 
 func (dist ChiSquared) Pdf(x float64) float64 {
+  lg, _ := math.Lgamma(dist.Degrees / 2)
+  return dist.pdf(x, lg)
+}
+
+func (dist ChiSquared) PdfSlice(xs, out []float64) {
+  lg, _ := math.Lgamma(dist.Degrees / 2)
+  for i, x := range xs {
+    out[i] = dist.pdf(x, lg)
+  }
+}
+
+func (dist ChiSquared) LogPdfSlice(xs, out []float64) {
+  lg, _ := math.Lgamma(dist.Degrees / 2)
+  for i, x := range xs {
+    switch {
+    case x < 0:
+      out[i] = math.Inf(-1)
+    case dist.Degrees == 2:
+      out[i] = (-x / 2) - math.Ln2
+    default:
+      out[i] = (((dist.Degrees / 2) - 1) * math.Log(x / 2)) - (x / 2) - lg - math.Ln2
+    }
+  }
+}
+
+// The density with log Γ(k/2) already computed.
+func (dist ChiSquared) pdf(x, lg float64) float64 {
   if x < 0 {
     return 0.0
   }
@@ -64,7 +92,6 @@ func (dist ChiSquared) Pdf(x float64) float64 {
     result := math.Exp(-x / 2) / 2
     return result
   }
-  lg, _ := math.Lgamma(dist.Degrees / 2)
   result := math.Exp((((dist.Degrees / 2) - 1) * math.Log(x / 2)) - (x / 2) - lg) / 2
   return result
 }
@@ -81,8 +108,17 @@ func (dist ChiSquared) Cdf(x float64) float64 {
   return result
 }
 
-func (dist ChiSquared) Random() float64 {
-  random := Gamma{ Shape: dist.Degrees / 2, Rate: 1.0 }.Random()
+func (dist ChiSquared) Quantile(p float64) float64 {
+  result := 2 * GammaIncLowerInv(dist.Degrees / 2, p)
+  return result
+}
+
+func (dist ChiSquared) RandomWith(r *rand.Rand) float64 {
+  random := Gamma{ Shape: dist.Degrees / 2, Rate: 1.0 }.RandomWith(r)
   value := 2 * random
   return value
 }
+
+func (dist ChiSquared) Random() float64 {
+  return dist.RandomWith(nil)
+}
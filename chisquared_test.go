@@ -51,6 +51,17 @@ func Test_ChiSquared(t *testing.T) {
     t.Fatal(err)
   }
 
+  quantiles := []inOut{
+    inOut{ in: 0.95,  out: 7.814727903251178 },
+    inOut{ in: 0.01,  out: 0.11483180189911719 },
+  }
+  for _, quantile := range quantiles {
+    out := ChiSquared{3.0}.Quantile(quantile.in)
+    if !floatsNanoEqual(out, quantile.out) {
+      t.Fatalf("\nQuantile of %f:\n  Expected: %f\n  Got: %f\n", quantile.in, quantile.out, out)
+    }
+  }
+
   sample := ChiSquared{2.0}
   if err := testSamples(sample); err != nil {
     t.Fatal(err)
@@ -61,3 +72,11 @@ func Benchmark_ChiSquared(b *testing.B) {
   dist := ChiSquared{2.0}
   runBenchmark(b, dist)
 }
+
+func Benchmark_ChiSquared_Pdf(b *testing.B) {
+  runPdfBenchmark(b, ChiSquared{ 5.0 }, 0, 20)
+}
+
+func Benchmark_ChiSquared_PdfSlice(b *testing.B) {
+  runPdfSliceBenchmark(b, ChiSquared{ 5.0 }, 0, 20)
+}
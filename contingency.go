@@ -0,0 +1,226 @@
+package prob
+
+import (
+  "math"
+  "sort"
+)
+
+// Fisher's test counts tables as extreme as the observed one up to this
+// relative tolerance, as R does.
+const fisher_tolerance = 1e-7
+
+func chiSquaredResult(statistic, degrees float64) TestResult {
+  return TestResult{
+    Statistic:    statistic,
+    Degrees:      degrees,
+    Degrees2:     math.NaN(),
+    PValue:       1 - ChiSquared{ degrees }.Cdf(statistic),
+    Alternative:  Greater,
+    Estimate:     math.NaN(),
+    Lower:        math.NaN(),
+    Upper:        math.NaN(),
+  }
+}
+
+// Pearson's chi-squared goodness-of-fit test of observed counts against
+// category probabilities, which are rescaled to sum to one. Estimated is the
+// number of parameters fitted from the data, each of which costs a degree of
+// freedom.
+//
+// See: https://en.wikipedia.org/wiki/Pearson%27s_chi-squared_test
+func ChiSquaredGOFTest(observed, probs []float64, estimated int) (TestResult, error) {
+  if len(observed) != len(probs) {
+    return TestResult{}, InvalidParamsError{ S: "Observed and Probs must be the same length." }
+  }
+  degrees := float64(len(observed) - 1 - estimated)
+  if degrees < 1 || estimated < 0 {
+    return TestResult{}, InvalidParamsError{ S: "There must be more categories than estimated parameters plus one." }
+  }
+  total, totalProb := 0.0, 0.0
+  for i := range observed {
+    if observed[i] < 0 || !(probs[i] > 0) {
+      return TestResult{}, InvalidParamsError{ S: "Observed must not be negative and Probs must be greater than zero." }
+    }
+    total += observed[i]
+    totalProb += probs[i]
+  }
+  if !(total > 0) {
+    return TestResult{}, InvalidParamsError{ S: "Observed must not all be zero." }
+  }
+  statistic := 0.0
+  for i := range observed {
+    expected := total * probs[i] / totalProb
+    diff := observed[i] - expected
+    statistic += diff * diff / expected
+  }
+  return chiSquaredResult(statistic, degrees), nil
+}
+
+// Pearson's chi-squared goodness-of-fit test of samples against a
+// distribution. The edges split the line into the bins (-∞, e₀], (e₀, e₁],
+// ..., (eₖ, ∞), so for discrete distributions each edge should sit on a
+// support point, and the bin probabilities come from the distribution's Cdf.
+func ChiSquaredDistributionTest(samples []float64, dist Distribution, edges []float64, estimated int) (TestResult, error) {
+  if err := dist.Validate(); err != nil {
+    return TestResult{}, err
+  }
+  if len(edges) < 1 || !sort.Float64sAreSorted(edges) {
+    return TestResult{}, InvalidParamsError{ S: "Edges must be non-empty and sorted in ascending order." }
+  }
+  observed := make([]float64, len(edges) + 1)
+  for _, x := range samples {
+    observed[sort.SearchFloat64s(edges, x)]++
+  }
+  probs := make([]float64, len(edges) + 1)
+  previous := 0.0
+  for i, edge := range edges {
+    current := dist.Cdf(edge)
+    probs[i] = current - previous
+    previous = current
+  }
+  probs[len(edges)] = 1 - previous
+  return ChiSquaredGOFTest(observed, probs, estimated)
+}
+
+// The expected counts of a contingency table under independence.
+func expectedCounts(table [][]float64) ([][]float64, error) {
+  if len(table) < 2 || len(table[0]) < 2 {
+    return nil, InvalidParamsError{ S: "Table must have at least two rows and two columns." }
+  }
+  rows := make([]float64, len(table))
+  cols := make([]float64, len(table[0]))
+  total := 0.0
+  for i, row := range table {
+    if len(row) != len(cols) {
+      return nil, InvalidParamsError{ S: "Table rows must all be the same length." }
+    }
+    for j, count := range row {
+      if count < 0 {
+        return nil, InvalidParamsError{ S: "Table counts must not be negative." }
+      }
+      rows[i] += count
+      cols[j] += count
+      total += count
+    }
+  }
+  result := make([][]float64, len(rows))
+  for i := range rows {
+    if rows[i] == 0 {
+      return nil, InvalidParamsError{ S: "Table rows and columns must not sum to zero." }
+    }
+    result[i] = make([]float64, len(cols))
+    for j := range cols {
+      if cols[j] == 0 {
+        return nil, InvalidParamsError{ S: "Table rows and columns must not sum to zero." }
+      }
+      result[i][j] = rows[i] * cols[j] / total
+    }
+  }
+  return result, nil
+}
+
+// Pearson's chi-squared test of independence of the rows and columns of a
+// contingency table. Yates' continuity correction is only applied to 2×2
+// tables.
+//
+// See: https://en.wikipedia.org/wiki/Pearson%27s_chi-squared_test#Testing_for_statistical_independence
+func ContingencyTest(table [][]float64, yates bool) (TestResult, error) {
+  expected, err := expectedCounts(table)
+  if err != nil {
+    return TestResult{}, err
+  }
+  yates = yates && len(table) == 2 && len(table[0]) == 2
+  statistic := 0.0
+  for i, row := range table {
+    for j, count := range row {
+      diff := math.Abs(count - expected[i][j])
+      if yates {
+        diff -= math.Min(0.5, diff)
+      }
+      statistic += diff * diff / expected[i][j]
+    }
+  }
+  degrees := float64((len(table) - 1) * (len(table[0]) - 1))
+  return chiSquaredResult(statistic, degrees), nil
+}
+
+// The G-test (likelihood-ratio test) of independence of the rows and columns
+// of a contingency table.
+//
+// See: https://en.wikipedia.org/wiki/G-test
+func GTest(table [][]float64) (TestResult, error) {
+  expected, err := expectedCounts(table)
+  if err != nil {
+    return TestResult{}, err
+  }
+  statistic := 0.0
+  for i, row := range table {
+    for j, count := range row {
+      if count > 0 {
+        statistic += 2 * count * math.Log(count / expected[i][j])
+      }
+    }
+  }
+  degrees := float64((len(table) - 1) * (len(table[0]) - 1))
+  return chiSquaredResult(statistic, degrees), nil
+}
+
+// Fisher's exact test of independence in a 2×2 table [[a, b], [c, d]],
+// conditioning on the margins so that a is hypergeometric. Greater tests for
+// an odds ratio above one. The statistic is a.
+//
+// See: https://en.wikipedia.org/wiki/Fisher%27s_exact_test
+func FisherExactTest(table [][]float64, alt Alternative) (TestResult, error) {
+  if err := validateAlternative(alt); err != nil {
+    return TestResult{}, err
+  }
+  if _, err := expectedCounts(table); err != nil {
+    return TestResult{}, err
+  }
+  if len(table) != 2 || len(table[0]) != 2 {
+    return TestResult{}, InvalidParamsError{ S: "Table must be 2×2." }
+  }
+  a, b, c, d := table[0][0], table[0][1], table[1][0], table[1][1]
+  for _, count := range []float64{ a, b, c, d } {
+    if count != math.Floor(count) {
+      return TestResult{}, InvalidParamsError{ S: "Table counts must be whole numbers." }
+    }
+  }
+  row, col, total := a + b, a + c, a + b + c + d
+  lo, hi := math.Max(0, col - (total - row)), math.Min(row, col)
+  logDenom := LogBinomialCoefficient(total, col)
+  probs := make([]float64, int(hi - lo) + 1)
+  for i := range probs {
+    k := lo + float64(i)
+    probs[i] = math.Exp(LogBinomialCoefficient(row, k) + LogBinomialCoefficient(total - row, col - k) - logDenom)
+  }
+  observed := int(a - lo)
+  p := 0.0
+  switch alt {
+  case Less:
+    for i := 0; i <= observed; i++ {
+      p += probs[i]
+    }
+  case Greater:
+    for i := observed; i < len(probs); i++ {
+      p += probs[i]
+    }
+  default:
+    for _, prob := range probs {
+      if prob <= probs[observed] * (1 + fisher_tolerance) {
+        p += prob
+      }
+    }
+  }
+  result := TestResult{
+    Statistic:    a,
+    Degrees:      math.NaN(),
+    Degrees2:     math.NaN(),
+    PValue:       math.Min(p, 1.0),
+    Alternative:  alt,
+    Estimate:     math.NaN(),
+    Lower:        math.NaN(),
+    Upper:        math.NaN(),
+  }
+  return result, nil
+}
@@ -0,0 +1,76 @@
+package prob
+
+import (
+  "testing"
+)
+
+type chiSquaredTest struct {
+  result      TestResult
+  err         error
+  statistic   float64
+  degrees     float64
+  pValue      float64
+}
+
+// Test against R's chisq.test and fisher.test.
+func Test_Contingency(t *testing.T) {
+  party := [][]float64{ { 762, 327, 468 }, { 484, 239, 477 } }
+  small := [][]float64{ { 12, 5 }, { 7, 9 } }
+  poisson := []float64{ 0, 1, 1, 2, 2, 2, 3, 3, 4, 5, 1, 0, 2, 3, 6, 2, 1, 1, 4, 2 }
+  examples := []chiSquaredTest{}
+  add := func(statistic, degrees, pValue float64) func(TestResult, error) {
+    return func(result TestResult, err error) {
+      examples = append(examples, chiSquaredTest{ result, err, statistic, degrees, pValue })
+    }
+  }
+  add(9.990143369175627, 4, 0.040594043344781214)(ChiSquaredGOFTest([]float64{ 89, 37, 30, 28, 2 }, []float64{ 40, 20, 20, 15, 5 }, 0))
+  add(1.2550978117369254, 5, 0.9394835448838217)(ChiSquaredDistributionTest(poisson, Poisson{ 2.0 }, []float64{ 0, 1, 2, 3, 4 }, 0))
+  add(1.2550978117369254, 4, 0.8689464644588855)(ChiSquaredDistributionTest(poisson, Poisson{ 2.0 }, []float64{ 0, 1, 2, 3, 4 }, 1))
+  add(30.070149095754672, 2, 2.953589183211757e-07)(ContingencyTest(party, true))
+  add(2.4305755196815575, 1, 0.11898920553214518)(ContingencyTest(small, false))
+  add(1.455996378814684, 1, 0.22756821457580978)(ContingencyTest(small, true))
+  add(30.016692613239655, 2, 3.0335979106388325e-07)(GTest(party))
+  for _, example := range examples {
+    if example.err != nil {
+      t.Fatal(example.err)
+    }
+    result := example.result
+    if !floatsPicoEqual(result.Statistic, example.statistic) || result.Degrees != example.degrees {
+      t.Fatalf("\nStatistic:\n  Expected: %f on %f\n  Got: %f on %f\n", example.statistic, example.degrees, result.Statistic, result.Degrees)
+    }
+    if !floatsNanoEqual(result.PValue, example.pValue) {
+      t.Fatalf("\nPValue:\n  Expected: %f\n  Got: %f\n", example.pValue, result.PValue)
+    }
+  }
+
+  if _, err := ContingencyTest([][]float64{ { 1, 2 }, { 3 } }, false); err == nil {
+    t.Fatal("\nExpected an error for a ragged table.")
+  }
+  if _, err := ChiSquaredGOFTest([]float64{ 1, 2 }, []float64{ 0.5, 0.5 }, 1); err == nil {
+    t.Fatal("\nExpected an error for too few degrees of freedom.")
+  }
+}
+
+func Test_Contingency_Fisher(t *testing.T) {
+  tea := [][]float64{ { 3, 1 }, { 1, 3 } }
+  small := [][]float64{ { 12, 5 }, { 7, 9 } }
+  examples := []struct{ table [][]float64; alt Alternative; out float64 }{
+    { tea,    TwoSided, 0.4857142857142857 },
+    { tea,    Greater,  0.24285714285714285 },
+    { tea,    Less,     0.9857142857142858 },
+    { small,  TwoSided, 0.16632006577356484 },
+    { small,  Greater,  0.11367380825716174 },
+  }
+  for _, example := range examples {
+    result, err := FisherExactTest(example.table, example.alt)
+    if err != nil {
+      t.Fatal(err)
+    }
+    if !floatsPicoEqual(result.PValue, example.out) {
+      t.Fatalf("\nPValue:\n  Expected: %f\n  Got: %f\n", example.out, result.PValue)
+    }
+  }
+  if _, err := FisherExactTest([][]float64{ { 1, 2, 3 }, { 4, 5, 6 } }, TwoSided); err == nil {
+    t.Fatal("\nExpected an error for a table larger than 2×2.")
+  }
+}
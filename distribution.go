@@ -1,7 +1,10 @@
 package prob
 
 import (
+  "errors"
+  "fmt"
   "math"
+  "math/rand"
 )
 
 // Distirbution is an interface for impementing continuous probability prob.
@@ -18,24 +21,168 @@ type Distribution interface {
   Pdf(float64)  float64
   Cdf(float64)  float64
   Random()      float64
+  RandomWith(*rand.Rand) float64
 }
 
-// Signifies bad parameters for a distribution.
-type InvalidParamsError struct{ S string }
-func (e InvalidParamsError) Error() string { return e.S }
+// Distributions may evaluate many points at once faster than one at a time,
+// usually by computing their normalizing constants once. Every method fills
+// out, which must be at least as long as xs.
+type pdfSlicer interface {
+  PdfSlice(xs, out []float64)
+}
+
+type logPdfSlicer interface {
+  LogPdfSlice(xs, out []float64)
+}
+
+type cdfSlicer interface {
+  CdfSlice(xs, out []float64)
+}
+
+// Distributions with a closed form or numerical inverse of their Cdf.
+type Quantiler interface {
+  Quantile(float64) float64
+}
+
+// Every distribution implements Distribution by value.
+var (
+  _ Distribution = Beta{}
+  _ Distribution = Binomial{}
+  _ Distribution = Cauchy{}
+  _ Distribution = ChiSquared{}
+  _ Distribution = Empirical{}
+  _ Distribution = Exponential{}
+  _ Distribution = F{}
+  _ Distribution = Gamma{}
+  _ Distribution = Geometric{}
+  _ Distribution = KDE{}
+  _ Distribution = Logistic{}
+  _ Distribution = LogNormal{}
+  _ Distribution = NegBinomial{}
+  _ Distribution = NoncentralChiSquared{}
+  _ Distribution = NoncentralF{}
+  _ Distribution = NoncentralT{}
+  _ Distribution = Normal{}
+  _ Distribution = Pareto{}
+  _ Distribution = Poisson{}
+  _ Distribution = StudentsT{}
+  _ Distribution = Uniform{}
+  _ Distribution = Weibull{}
+)
+
+// Every InvalidParamsError matches ErrInvalidParams with errors.Is.
+var ErrInvalidParams = errors.New("invalid parameters")
+
+// Signifies bad parameters for a distribution. Dist and Param name the
+// distribution and its parameter, Value is the offending value and
+// Constraint completes "Param must ...". Errors that are not about a single
+// parameter, such as those from the hypothesis tests, only carry the
+// message S.
+type InvalidParamsError struct {
+  Dist        string
+  Param       string
+  Value       float64
+  Constraint  string
+  S           string
+}
 
-// Takes n samples from a distribution.
-func Sample(dist Distribution, n int) []float64 {
+func (e InvalidParamsError) Error() string {
+  if e.Constraint == "" {
+    return e.S
+  }
+  return fmt.Sprintf("%s: %s must %s, got %v.", e.Dist, e.Param, e.Constraint, e.Value)
+}
+
+func (e InvalidParamsError) Is(target error) bool {
+  return target == ErrInvalidParams
+}
+
+// Returns an InvalidParamsError unless ok holds and value is finite. A NaN or
+// infinite value is reported as failing to be finite, whatever the
+// constraint.
+func checkParam(dist, param string, value float64, ok bool, constraint string) error {
+  if math.IsNaN(value) || math.IsInf(value, 0) {
+    constraint = "be finite"
+  } else if ok {
+    return nil
+  }
+  return InvalidParamsError{ Dist: dist, Param: param, Value: value, Constraint: constraint }
+}
+
+func checkPositive(dist, param string, value float64) error {
+  return checkParam(dist, param, value, value > 0, "be greater than zero")
+}
+
+func checkNonNegative(dist, param string, value float64) error {
+  return checkParam(dist, param, value, value >= 0, "not be negative")
+}
+
+func checkFinite(dist, param string, value float64) error {
+  return checkParam(dist, param, value, true, "be finite")
+}
+
+func checkProb(dist, param string, value float64) error {
+  return checkParam(dist, param, value, value >= 0 && value <= 1, "be between zero and one")
+}
+
+// Fills out with the density of each point of xs. out must be at least as
+// long as xs.
+func PdfSlice(dist Distribution, xs, out []float64) {
+  if slicer, ok := dist.(pdfSlicer); ok {
+    slicer.PdfSlice(xs, out)
+    return
+  }
+  for i, x := range xs {
+    out[i] = dist.Pdf(x)
+  }
+}
+
+// Fills out with the log of the density of each point of xs. Distributions
+// that can work in log space keep their precision where the density
+// underflows.
+func LogPdfSlice(dist Distribution, xs, out []float64) {
+  if slicer, ok := dist.(logPdfSlicer); ok {
+    slicer.LogPdfSlice(xs, out)
+    return
+  }
+  for i, x := range xs {
+    out[i] = math.Log(dist.Pdf(x))
+  }
+}
+
+// Fills out with the cumulative probability of each point of xs. out must be
+// at least as long as xs.
+func CdfSlice(dist Distribution, xs, out []float64) {
+  if slicer, ok := dist.(cdfSlicer); ok {
+    slicer.CdfSlice(xs, out)
+    return
+  }
+  for i, x := range xs {
+    out[i] = dist.Cdf(x)
+  }
+}
+
+// Fills out with the quantile of each probability in ps. out must be at least
+// as long as ps. Probabilities the Quantile rejects with NaN, such as those
+// outside [0, 1], come through as NaN rather than stopping the fill.
+func QuantileSlice(dist Quantiler, ps, out []float64) {
+  for i, p := range ps {
+    out[i] = dist.Quantile(p)
+  }
+}
+
+// Takes n samples from a distribution using the global source in math/rand.
+// SampleWith takes them with quasi-random or stratified uniforms instead.
+func Sample(dist Distribution, n int) ([]float64, error) {
+  if err := dist.Validate(); err != nil {
+    return nil, err
+  }
   if n <= 0 {
-    return []float64{}
+    return []float64{}, nil
   }
   result := make([]float64, n)
-  for i := 0; i < n; i++ {
-    value := dist.Random()
-    if math.IsNaN(value) {
-      return []float64{}
-    }
-    result[i] = value
-  }
-  return result
+  if err := sampleInto(dist, result, nil, 0); err != nil {
+    return nil, err
+  }
+  return result, nil
 }
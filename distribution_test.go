@@ -0,0 +1,134 @@
+package prob
+
+import (
+  "errors"
+  "math"
+  "testing"
+)
+
+func Test_InvalidParamsError(t *testing.T) {
+  nan, inf := math.NaN(), math.Inf(1)
+  invalid := []Distribution{
+    Normal{ Mu: nan, Sigma: 1 },
+    Normal{ Mu: 0, Sigma: inf },
+    Normal{ Mu: 0, Sigma: -1 },
+    Exponential{ Lambda: nan },
+    Uniform{ Min: math.Inf(-1), Max: 1 },
+    Uniform{ Min: 1, Max: 0 },
+    Cauchy{ Location: nan, Scale: 1 },
+    Cauchy{ Location: 0, Scale: 0 },
+    Gamma{ Shape: inf, Rate: 1 },
+    Beta{ Alpha: 1, Beta: nan },
+    StudentsT{ Degrees: nan },
+    LogNormal{ Mu: inf, Sigma: 1 },
+    Weibull{ Scale: 1, Shape: nan },
+    Binomial{ Trials: 10, Prob: nan },
+    Binomial{ Trials: 10.5, Prob: 0.5 },
+    Geometric{ Prob: 0 },
+    NegBinomial{ Failures: inf, Prob: 0.5 },
+    Poisson{ Mu: nan },
+    Empirical{},
+    Empirical{ Values: []float64{ 0, nan } },
+  }
+  for _, dist := range invalid {
+    err := dist.Validate()
+    if !errors.Is(err, ErrInvalidParams) {
+      t.Fatalf("\n%#v:\n  Expected: %v\n  Got: %v\n", dist, ErrInvalidParams, err)
+    }
+  }
+
+  var params InvalidParamsError
+  err := Normal{ Mu: 0, Sigma: -2 }.Validate()
+  if !errors.As(err, &params) || params.Dist != "Normal" || params.Param != "Sigma" || params.Value != -2 {
+    t.Fatalf("\n  Expected: Normal Sigma -2\n  Got: %#v\n", err)
+  }
+  if expected := "Normal: Sigma must not be negative, got -2."; err.Error() != expected {
+    t.Fatalf("\n  Expected: %s\n  Got: %s\n", expected, err.Error())
+  }
+
+  // NaN and infinities fail to be finite rather than the parameter's own
+  // constraint.
+  nonFinite := []struct {
+    dist        Distribution
+    param       string
+    value       float64
+    constraint  string
+  }{
+    { Normal{ Mu: 0, Sigma: inf }, "Sigma", inf, "be finite" },
+    { Normal{ Mu: 0, Sigma: -inf }, "Sigma", -inf, "be finite" },
+    { Uniform{ Min: 0, Max: inf }, "Max", inf, "be finite" },
+    { Exponential{ Lambda: nan }, "Lambda", nan, "be finite" },
+    { Uniform{ Min: 1, Max: 0 }, "Max", 0, "be greater than Min" },
+  }
+  for _, example := range nonFinite {
+    err := example.dist.Validate()
+    if !errors.As(err, &params) || params.Param != example.param || params.Constraint != example.constraint || !(params.Value == example.value || checkNaN(params.Value, example.value)) {
+      t.Fatalf("\n%#v:\n  Expected: %s must %s, got %v\n  Got: %#v\n", example.dist, example.param, example.constraint, example.value, err)
+    }
+  }
+  if expected := "Uniform: Max must be finite, got +Inf."; (Uniform{ Min: 0, Max: inf }).Validate().Error() != expected {
+    t.Fatalf("\n  Expected: %s\n  Got: %s\n", expected, (Uniform{ Min: 0, Max: inf }).Validate().Error())
+  }
+
+  _, err = NewKDE([]float64{ 1, 2, 3 }, GaussianKernel, -1)
+  if !errors.As(err, &params) || params.Dist != "KDE" || params.Param != "Bandwidth" {
+    t.Fatalf("\n  Expected: KDE Bandwidth\n  Got: %#v\n", err)
+  }
+
+  err = InvalidParamsError{ S: "Samples must not be empty." }
+  if !errors.Is(err, ErrInvalidParams) || err.Error() != "Samples must not be empty." {
+    t.Fatalf("\n  Expected: Samples must not be empty.\n  Got: %v\n", err)
+  }
+}
+
+func Test_Slices(t *testing.T) {
+  dists := []Distribution{
+    Normal{ 1.0, 2.0 },
+    Gamma{ 2.5, 1.5 },
+    Gamma{ 1.0, 2.0 },
+    ChiSquared{ 2.0 },
+    ChiSquared{ 5.0 },
+    StudentsT{ 5.5 },
+    StudentsT{ 1e6 },
+    Beta{ 2.5, 3.5 },
+    Beta{ 1.0, 3.0 },
+    Exponential{ 2.0 },
+  }
+  xs := []float64{ -1, 0, 1e-3, 0.25, 0.5, 1, 2.5, 10, 1e3 }
+  out := make([]float64, len(xs))
+  for _, dist := range dists {
+    PdfSlice(dist, xs, out)
+    for i, x := range xs {
+      if expected := dist.Pdf(x); !floatsPicoEqual(out[i], expected) && !checkNaN(out[i], expected) {
+        t.Fatalf("\n%#v PdfSlice(%f):\n  Expected: %v\n  Got: %v\n", dist, x, expected, out[i])
+      }
+    }
+    LogPdfSlice(dist, xs, out)
+    for i, x := range xs {
+      expected := math.Log(dist.Pdf(x))
+      if !floatsPicoEqual(out[i], expected) && !checkInf(out[i], expected) && !checkNaN(out[i], expected) && !(expected < -700) {
+        t.Fatalf("\n%#v LogPdfSlice(%f):\n  Expected: %v\n  Got: %v\n", dist, x, expected, out[i])
+      }
+    }
+    CdfSlice(dist, xs, out)
+    for i, x := range xs {
+      if expected := dist.Cdf(x); !floatsPicoEqual(out[i], expected) && !checkNaN(out[i], expected) {
+        t.Fatalf("\n%#v CdfSlice(%f):\n  Expected: %v\n  Got: %v\n", dist, x, expected, out[i])
+      }
+    }
+  }
+
+  // The log density keeps its precision where the density underflows.
+  LogPdfSlice(Normal{ 0.0, 1.0 }, []float64{ 40 }, out)
+  if expected := -800 - (math.Log(2 * math.Pi) / 2); !floatsPicoEqual(out[0], expected) {
+    t.Fatalf("\nLogPdfSlice(40):\n  Expected: %v\n  Got: %v\n", expected, out[0])
+  }
+
+  ps := []float64{ 0.025, 0.5, 0.975 }
+  QuantileSlice(Normal{ 0.0, 1.0 }, ps, out)
+  for i, p := range ps {
+    if expected := (Normal{ 0.0, 1.0 }).Quantile(p); out[i] != expected {
+      t.Fatalf("\nQuantileSlice(%f):\n  Expected: %v\n  Got: %v\n", p, expected, out[i])
+    }
+  }
+}
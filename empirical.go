@@ -0,0 +1,236 @@
+package prob
+
+import (
+  "math"
+  "math/rand"
+  "sort"
+)
+
+//The Empirical Distribution is a discrete probability distribution
+// placing mass on each observed value, optionally weighted. Values must be
+// sorted in ascending order; the constructors take care of that.
+//
+// See: https://en.wikipedia.org/wiki/Empirical_distribution_function
+type Empirical struct {
+  Values      []float64   `json:"values"`
+  Weights     []float64   `json:"weights,omitempty"`
+  cumulative  []float64
+}
+
+func NewEmpirical(values []float64) (Empirical, error) {
+  return NewWeightedEmpirical(values, nil)
+}
+
+// A nil weights slice gives every value the same weight.
+func NewWeightedEmpirical(values []float64, weights []float64) (Empirical, error) {
+  dist := Empirical{}
+  if weights != nil && len(weights) != len(values) {
+    return dist, InvalidParamsError{ Dist: "Empirical", Param: "Weights", Value: float64(len(weights)), Constraint: "have the same length as Values" }
+  }
+  index := make([]int, len(values))
+  for i := range index {
+    index[i] = i
+  }
+  sort.SliceStable(index, func(i, j int) bool {
+    return values[index[i]] < values[index[j]]
+  })
+  dist.Values = make([]float64, len(values))
+  for i, j := range index {
+    dist.Values[i] = values[j]
+  }
+  if weights != nil {
+    dist.Weights = make([]float64, len(weights))
+    for i, j := range index {
+      dist.Weights[i] = weights[j]
+    }
+  }
+  if err := dist.Validate(); err != nil {
+    return dist, err
+  }
+  dist.cumulative = dist.cumWeights()
+  return dist, nil
+}
+
+func (dist Empirical) Validate() error {
+  if len(dist.Values) == 0 {
+    return InvalidParamsError{ Dist: "Empirical", Param: "Values", Value: 0, Constraint: "not be empty" }
+  }
+  if dist.Weights != nil && len(dist.Weights) != len(dist.Values) {
+    return InvalidParamsError{ Dist: "Empirical", Param: "Weights", Value: float64(len(dist.Weights)), Constraint: "have the same length as Values" }
+  }
+  for i, value := range dist.Values {
+    if err := checkFinite("Empirical", "Values", value); err != nil {
+      return err
+    }
+    if i > 0 && value < dist.Values[i-1] {
+      return InvalidParamsError{ Dist: "Empirical", Param: "Values", Value: value, Constraint: "be sorted in ascending order" }
+    }
+  }
+  total := 0.0
+  for _, weight := range dist.Weights {
+    if err := checkNonNegative("Empirical", "Weights", weight); err != nil {
+      return err
+    }
+    total += weight
+  }
+  if dist.Weights != nil && total <= 0 {
+    return InvalidParamsError{ Dist: "Empirical", Param: "Weights", Value: total, Constraint: "sum to more than zero" }
+  }
+  return nil
+}
+
+func (dist Empirical) weight(i int) float64 {
+  if dist.Weights == nil {
+    return 1.0
+  }
+  return dist.Weights[i]
+}
+
+// Running totals of the weights, cached by the constructors.
+func (dist Empirical) cumWeights() []float64 {
+  if dist.cumulative != nil {
+    return dist.cumulative
+  }
+  result := make([]float64, len(dist.Values))
+  total := 0.0
+  for i := range dist.Values {
+    total += dist.weight(i)
+    result[i] = total
+  }
+  return result
+}
+
+// The weighted central moment of the given order.
+func (dist Empirical) moment(order float64) float64 {
+  mean := dist.Mean()
+  total, sum := 0.0, 0.0
+  for i, value := range dist.Values {
+    weight := dist.weight(i)
+    sum += weight * math.Pow(value - mean, order)
+    total += weight
+  }
+  return sum / total
+}
+
+func (dist Empirical) Mean() float64 {
+  total, sum := 0.0, 0.0
+  for i, value := range dist.Values {
+    weight := dist.weight(i)
+    sum += weight * value
+    total += weight
+  }
+  return sum / total
+}
+
+func (dist Empirical) Variance() float64 {
+  return dist.moment(2)
+}
+
+func (dist Empirical) Skewness() float64 {
+  variance := dist.Variance()
+  result := dist.moment(3) / math.Pow(variance, 1.5)
+  return result
+}
+
+// Returns the excess kurtosis of the sample.
+func (dist Empirical) Kurtosis() float64 {
+  variance := dist.Variance()
+  result := dist.moment(4) / (variance * variance) - 3
+  return result
+}
+
+func (dist Empirical) StdDev() float64 {
+  variance := dist.Variance()
+  result := math.Sqrt(variance)
+  return result
+}
+
+func (dist Empirical) RelStdDev() float64 {
+  mean := dist.Mean()
+  stdDev := dist.StdDev()
+  result := stdDev / mean
+  return result
+}
+
+// Returns the probability mass placed on x.
+func (dist Empirical) Pdf(x float64) float64 {
+  cum := dist.cumWeights()
+  lo := sort.SearchFloat64s(dist.Values, x)
+  hi := sort.Search(len(dist.Values), func(i int) bool { return dist.Values[i] > x })
+  if lo == hi {
+    return 0.0
+  }
+  below := 0.0
+  if lo > 0 {
+    below = cum[lo-1]
+  }
+  result := (cum[hi-1] - below) / cum[len(cum)-1]
+  return result
+}
+
+func (dist Empirical) Cdf(x float64) float64 {
+  cum := dist.cumWeights()
+  n := sort.Search(len(dist.Values), func(i int) bool { return dist.Values[i] > x })
+  if n == 0 {
+    return 0.0
+  }
+  result := cum[n-1] / cum[len(cum)-1]
+  return result
+}
+
+// Linearly interpolates between the sorted values, with each value placed at
+// the fraction of the weight preceding it. Unweighted this is the usual
+// (n-1)p rule.
+func (dist Empirical) Quantile(p float64) float64 {
+  if p < 0 || p > 1 || math.IsNaN(p) {
+    return math.NaN()
+  }
+  n := len(dist.Values)
+  if n == 1 {
+    return dist.Values[0]
+  }
+  cum := dist.cumWeights()
+  span := cum[n-2]
+  if span <= 0 {
+    return dist.Values[n-1]
+  }
+  target := p * span
+  i := sort.Search(n - 1, func(i int) bool { return cum[i] >= target })
+  if i >= n - 1 {
+    return dist.Values[n-1]
+  }
+  lower := 0.0
+  if i > 0 {
+    lower = cum[i-1]
+  }
+  if cum[i] == lower {
+    return dist.Values[i+1]
+  }
+  frac := (target - lower) / (cum[i] - lower)
+  result := dist.Values[i] + frac * (dist.Values[i+1] - dist.Values[i])
+  return result
+}
+
+// Bootstrap resampling: draws one of the values in proportion to its weight.
+func (dist Empirical) RandomWith(r *rand.Rand) float64 {
+  if dist.Weights == nil {
+    return dist.Values[randIntn(r, len(dist.Values))]
+  }
+  return dist.inverse(randFloat64(r))
+}
+
+// The value where the weights summed in order first pass p of the total, so
+// each value takes a share of [0, 1) in proportion to its weight.
+func (dist Empirical) inverse(p float64) float64 {
+  cum := dist.cumWeights()
+  u := p * cum[len(cum)-1]
+  i := sort.Search(len(cum), func(i int) bool { return cum[i] > u })
+  if i == len(cum) {
+    i = len(cum) - 1
+  }
+  return dist.Values[i]
+}
+
+func (dist Empirical) Random() float64 {
+  return dist.RandomWith(nil)
+}
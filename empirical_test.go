@@ -0,0 +1,95 @@
+package prob
+
+import "testing"
+
+func Test_Empirical(t *testing.T) {
+  unweighted, err := NewEmpirical([]float64{ 10.0, 3.0, 1.0, 4.0, 2.0 })
+  if err != nil {
+    t.Fatal(err)
+  }
+  weighted, err := NewWeightedEmpirical([]float64{ 5.0, 0.0, 2.0, 1.0 }, []float64{ 4.0, 1.0, 3.0, 2.0 })
+  if err != nil {
+    t.Fatal(err)
+  }
+  examples := []distributionTest{
+    distributionTest{
+      dist:       unweighted,
+      mean:       4.0,
+      variance:   10.0,
+      stdDev:     3.1622776601683795,
+      relStdDev:  0.7905694150420949,
+      skewness:   1.1384199576606167,
+      kurtosis:   -0.212,
+      pdf: []inOut{
+        inOut{ in: 3.0,   out: 0.2 },
+        inOut{ in: 5.0,   out: 0.0 },
+        inOut{ in: 10.0,  out: 0.2 },
+      },
+      cdf: []inOut{
+        inOut{ in: 0.5,   out: 0.0 },
+        inOut{ in: 3.0,   out: 0.6 },
+        inOut{ in: 3.5,   out: 0.6 },
+        inOut{ in: 10.0,  out: 1.0 },
+      },
+    },
+    distributionTest{
+      dist:       weighted,
+      mean:       2.8,
+      variance:   3.56,
+      stdDev:     1.8867962264113207,
+      relStdDev:  0.6738557951469003,
+      skewness:   0.11076382341639522,
+      kurtosis:   -1.6003029920464584,
+      pdf: []inOut{
+        inOut{ in: 0.0,   out: 0.1 },
+        inOut{ in: 2.0,   out: 0.3 },
+        inOut{ in: 3.0,   out: 0.0 },
+      },
+      cdf: []inOut{
+        inOut{ in: -1.0,  out: 0.0 },
+        inOut{ in: 1.0,   out: 0.3 },
+        inOut{ in: 4.0,   out: 0.6 },
+        inOut{ in: 5.0,   out: 1.0 },
+      },
+    },
+  }
+
+  if err := testValues(examples); err != nil {
+    t.Fatal(err)
+  }
+
+  quantiles := []struct{ dist Empirical; in, out float64 }{
+    { unweighted, 0.0,   1.0 },
+    { unweighted, 0.5,   3.0 },
+    { unweighted, 0.875, 7.0 },
+    { unweighted, 1.0,   10.0 },
+    { weighted,   0.25,  1.25 },
+    { weighted,   0.5,   2.0 },
+    { weighted,   1.0,   5.0 },
+  }
+  for _, example := range quantiles {
+    out := example.dist.Quantile(example.in)
+    if !floatsPicoEqual(out, example.out) {
+      t.Fatalf("\nQuantile of %f:\n  Expected: %f\n  Got: %f\n", example.in, example.out, out)
+    }
+  }
+
+  if _, err := NewWeightedEmpirical([]float64{ 1.0, 2.0 }, []float64{ 1.0 }); err == nil {
+    t.Fatal("\nExpected an error for mismatched weights.")
+  }
+  if err := (Empirical{ Values: []float64{ 2.0, 1.0 } }).Validate(); err == nil {
+    t.Fatal("\nExpected an error for unsorted values.")
+  }
+
+  if err := testSamples(unweighted); err != nil {
+    t.Fatal(err)
+  }
+  if err := testSamples(weighted); err != nil {
+    t.Fatal(err)
+  }
+}
+
+func Benchmark_Empirical(b *testing.B) {
+  dist, _ := NewEmpirical([]float64{ 10.0, 3.0, 1.0, 4.0, 2.0 })
+  runBenchmark(b, dist)
+}
@@ -6,7 +6,8 @@ import (
 )
 
 //The Exponential Distribution is a continuous probability distribution
-// with parameters λ > 0.
+// with parameters λ > 0. Note: λ is the scale, which is also the mean, not
+// the rate; use NewExponentialRate to construct it from a rate.
 //
 // See: https://en.wikipedia.org/wiki/Exponential_distribution
 type Exponential struct {
@@ -21,9 +22,22 @@ func NewExponential(lambda float64) (Exponential, error) {
   return dist, nil
 }
 
+// The exponential distribution with the given rate, the reciprocal of λ.
+func NewExponentialRate(rate float64) (Exponential, error) {
+  if err := checkPositive("Exponential", "Rate", rate); err != nil {
+    return Exponential{}, err
+  }
+  return NewExponential(1 / rate)
+}
+
+// The exponential distribution with the given scale, which is λ itself.
+func NewExponentialScale(scale float64) (Exponential, error) {
+  return NewExponential(scale)
+}
+
 func (dist Exponential) Validate() error {
-  if dist.Lambda <= 0 {
-    return InvalidParamsError{ "Lambda must be greater than zero." }
+  if err := checkPositive("Exponential", "Lambda", dist.Lambda); err != nil {
+    return err
   }
   return nil
 }
@@ -69,8 +83,20 @@ func (dist Exponential) Cdf(x float64) float64 {
   return result
 }
 
-func (dist Exponential) Random() float64 {
+func (dist Exponential) Quantile(p float64) float64 {
+  if p < 0 || p > 1 || math.IsNaN(p) {
+    return math.NaN()
+  }
+  result := -dist.Lambda * math.Log1p(-p)
+  return result
+}
+
+func (dist Exponential) RandomWith(r *rand.Rand) float64 {
   // value := -1 * dist.Lambda * math.Log1p(-1 * rand.Float64())
-  value := rand.ExpFloat64() * dist.Lambda
+  value := randExpFloat64(r) * dist.Lambda
   return value
 }
+
+func (dist Exponential) Random() float64 {
+  return dist.RandomWith(nil)
+}
@@ -59,3 +59,22 @@ func Benchmark_Exponential(b *testing.B) {
   dist := Exponential{4.0}
   runBenchmark(b, dist)
 }
+
+func Test_Exponential_Constructors(t *testing.T) {
+  dist, err := NewExponentialRate(4)
+  if err != nil || !floatsPicoEqual(dist.Mean(), 0.25) || !floatsPicoEqual(dist.Pdf(0), 4) {
+    t.Fatalf("\nRate:\n  Expected: mean %f\n  Got: %v, %v\n", 0.25, dist, err)
+  }
+  dist, err = NewExponentialScale(4)
+  if err != nil || dist.Lambda != 4 || !floatsPicoEqual(dist.Mean(), 4) {
+    t.Fatalf("\nScale:\n  Expected: mean %f\n  Got: %v, %v\n", 4.0, dist, err)
+  }
+  for _, invalid := range []float64{ 0, -1 } {
+    if _, err := NewExponentialRate(invalid); err == nil {
+      t.Fatalf("\nExpected an error for rate %v.", invalid)
+    }
+    if _, err := NewExponentialScale(invalid); err == nil {
+      t.Fatalf("\nExpected an error for scale %v.", invalid)
+    }
+  }
+}
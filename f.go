@@ -0,0 +1,129 @@
+package prob
+
+import (
+  "math"
+  "math/rand"
+)
+
+//The F Distribution (Fisher–Snedecor) is a continuous probability distribution
+// with parameters d1 > 0 and d2 > 0.
+//
+// See: https://en.wikipedia.org/wiki/F-distribution
+type F struct {
+  Degrees1  float64  `json:"degrees1"`
+  Degrees2  float64  `json:"degrees2"`
+}
+
+func NewF(degrees1, degrees2 float64) (F, error) {
+  dist := F{ degrees1, degrees2 }
+  if err := dist.Validate(); err != nil {
+    return dist, err
+  }
+  return dist, nil
+}
+
+func (dist F) Validate() error {
+  if err := checkPositive("F", "Degrees1", dist.Degrees1); err != nil {
+    return err
+  }
+  if err := checkPositive("F", "Degrees2", dist.Degrees2); err != nil {
+    return err
+  }
+  return nil
+}
+
+func (dist F) Mean() float64 {
+  if dist.Degrees2 <= 2 {
+    return math.NaN()
+  }
+  result := dist.Degrees2 / (dist.Degrees2 - 2)
+  return result
+}
+
+func (dist F) Variance() float64 {
+  d1, d2 := dist.Degrees1, dist.Degrees2
+  if d2 <= 2 {
+    return math.NaN()
+  }
+  if d2 <= 4 {
+    return math.Inf(1)
+  }
+  result := 2 * d2 * d2 * (d1 + d2 - 2) / (d1 * (d2 - 2) * (d2 - 2) * (d2 - 4))
+  return result
+}
+
+func (dist F) Skewness() float64 {
+  d1, d2 := dist.Degrees1, dist.Degrees2
+  if d2 <= 6 {
+    return math.NaN()
+  }
+  result := ((2 * d1) + d2 - 2) * math.Sqrt(8 * (d2 - 4)) / ((d2 - 6) * math.Sqrt(d1 * (d1 + d2 - 2)))
+  return result
+}
+
+func (dist F) Kurtosis() float64 {
+  d1, d2 := dist.Degrees1, dist.Degrees2
+  if d2 <= 8 {
+    return math.NaN()
+  }
+  numer := (d1 * ((5 * d2) - 22) * (d1 + d2 - 2)) + ((d2 - 4) * (d2 - 2) * (d2 - 2))
+  result := 3 + (12 * numer / (d1 * (d2 - 6) * (d2 - 8) * (d1 + d2 - 2)))
+  return result
+}
+
+func (dist F) StdDev() float64 {
+  result := math.Sqrt(dist.Variance())
+  return result
+}
+
+func (dist F) RelStdDev() float64 {
+  result := dist.StdDev() / dist.Mean()
+  return result
+}
+
+func (dist F) Pdf(x float64) float64 {
+  d1, d2 := dist.Degrees1, dist.Degrees2
+  if x < 0 {
+    return 0.0
+  }
+  if x == 0 {
+    switch {
+    case d1 < 2:
+      return math.Inf(1)
+    case d1 == 2:
+      return 1.0
+    }
+    return 0.0
+  }
+  result := math.Exp(((d1 / 2) * math.Log(d1 * x)) + ((d2 / 2) * math.Log(d2)) - (((d1 + d2) / 2) * math.Log((d1 * x) + d2)) - math.Log(x) - LogBeta(d1 / 2, d2 / 2))
+  return result
+}
+
+func (dist F) Cdf(x float64) float64 {
+  if x <= 0 {
+    return 0.0
+  }
+  d1, d2 := dist.Degrees1, dist.Degrees2
+  result := RegBetaInc(d1 / 2, d2 / 2, d1 * x / ((d1 * x) + d2))
+  return result
+}
+
+func (dist F) Quantile(p float64) float64 {
+  if p == 1 {
+    return math.Inf(1)
+  }
+  x := RegBetaIncInv(dist.Degrees1 / 2, dist.Degrees2 / 2, p)
+  result := dist.Degrees2 * x / (dist.Degrees1 * (1 - x))
+  return result
+}
+
+func (dist F) RandomWith(r *rand.Rand) float64 {
+  x1 := ChiSquared{ dist.Degrees1 }.RandomWith(r)
+  x2 := ChiSquared{ dist.Degrees2 }.RandomWith(r)
+  result := (x1 / dist.Degrees1) / (x2 / dist.Degrees2)
+  return result
+}
+
+func (dist F) Random() float64 {
+  return dist.RandomWith(nil)
+}
@@ -0,0 +1,77 @@
+package prob
+
+import (
+  "math"
+  "testing"
+)
+
+// Test against numerical integration of the density, and the closed form
+// 1 - (1 + 2x/d2)^(-d2/2) of the cdf when d1 = 2.
+func Test_F(t *testing.T) {
+  examples := []distributionTest{
+    distributionTest{
+      dist:       F{5.0, 12.0},
+      mean:       1.2,
+      variance:   1.08,
+      stdDev:     1.0392304845413263,
+      relStdDev:  0.8660254037844386,
+      skewness:   3.079201435678004,
+      kurtosis:   27.333333333333332,
+      pdf: []inOut{
+        inOut{ in: 1.5,   out: 0.2922347178606402 },
+        inOut{ in: 0.5,   out: 0.6977761238719494 },
+        inOut{ in: -1.0,  out: 0.0 },
+      },
+      cdf: []inOut{
+        inOut{ in: 1.5,   out: 0.738883234317461 },
+        inOut{ in: 0.5,   out: 0.22923615040316778 },
+        inOut{ in: -1.0,  out: 0.0 },
+      },
+    },
+    distributionTest{
+      dist:       F{2.0, 3.5},
+      mean:       3.5 / 1.5,
+      variance:   math.Inf(1),
+      stdDev:     math.Inf(1),
+      relStdDev:  math.Inf(1),
+      skewness:   math.NaN(),
+      kurtosis:   math.NaN(),
+      pdf: []inOut{
+        inOut{ in: 0.0,   out: 1.0 },
+        inOut{ in: 1.5,   out: 0.18225329601672666 },
+      },
+      cdf: []inOut{
+        inOut{ in: 0.0,   out: 0.0 },
+        inOut{ in: 1.5,   out: 0.6615295931117935 },
+      },
+    },
+  }
+  if err := testValues(examples); err != nil {
+    t.Fatal(err)
+  }
+
+  quantiles := []inOut{
+    inOut{ in: 0.95,  out: 3.1058752390841224 },
+    inOut{ in: 0.0,   out: 0.0 },
+  }
+  for _, quantile := range quantiles {
+    out := F{5.0, 12.0}.Quantile(quantile.in)
+    if !floatsNanoEqual(out, quantile.out) {
+      t.Fatalf("\nQuantile of %f:\n  Expected: %f\n  Got: %f\n", quantile.in, quantile.out, out)
+    }
+  }
+
+  if _, err := NewF(5.0, 0.0); err == nil {
+    t.Fatal("\nExpected an error for zero Degrees2.")
+  }
+
+  sample := F{10.0, 30.0}
+  if err := testSamples(sample); err != nil {
+    t.Fatal(err)
+  }
+}
+
+func Benchmark_F(b *testing.B) {
+  dist := F{10.0, 30.0}
+  runBenchmark(b, dist)
+}
@@ -22,12 +22,33 @@ func NewGamma(shape float64, Rate float64) (Gamma, error) {
   return dist, nil
 }
 
+// The gamma distribution with the given shape and scale, the reciprocal of
+// the rate.
+func NewGammaShapeScale(shape, scale float64) (Gamma, error) {
+  if err := checkPositive("Gamma", "Scale", scale); err != nil {
+    return Gamma{}, err
+  }
+  return NewGamma(shape, 1 / scale)
+}
+
+// The gamma distribution with the given mean and variance, using
+// shape = mean² / variance and rate = mean / variance.
+func NewGammaMeanVariance(mean, variance float64) (Gamma, error) {
+  if err := checkPositive("Gamma", "Mean", mean); err != nil {
+    return Gamma{}, err
+  }
+  if err := checkPositive("Gamma", "Variance", variance); err != nil {
+    return Gamma{}, err
+  }
+  return NewGamma(mean * mean / variance, mean / variance)
+}
+
 func (dist Gamma) Validate() error {
-  if dist.Shape <= 0 {
-    return InvalidParamsError{ "Shape must be greater than zero." }
+  if err := checkPositive("Gamma", "Shape", dist.Shape); err != nil {
+    return err
   }
-  if dist.Rate <= 0 {
-    return InvalidParamsError{ "Rate must be greater than zero." }
+  if err := checkPositive("Gamma", "Rate", dist.Rate); err != nil {
+    return err
   }
   return nil
 }
@@ -63,6 +84,35 @@ func (dist Gamma) RelStdDev() float64 {
 }
 
 func (dist Gamma) Pdf(x float64) float64 {
+  lgamma, _ := math.Lgamma(dist.Shape)
+  return dist.pdf(x, lgamma)
+}
+
+func (dist Gamma) PdfSlice(xs, out []float64) {
+  lgamma, _ := math.Lgamma(dist.Shape)
+  for i, x := range xs {
+    out[i] = dist.pdf(x, lgamma)
+  }
+}
+
+func (dist Gamma) LogPdfSlice(xs, out []float64) {
+  lgamma, _ := math.Lgamma(dist.Shape)
+  logRate := math.Log(dist.Rate)
+  for i, x := range xs {
+    if x < 0 || (x == 0 && dist.Shape != 1) {
+      out[i] = math.Inf(-1)
+      continue
+    }
+    result := logRate - (x * dist.Rate)
+    if dist.Shape != 1 {
+      result += ((dist.Shape - 1) * math.Log(x * dist.Rate)) - lgamma
+    }
+    out[i] = result
+  }
+}
+
+// The density with log Γ(α) already computed.
+func (dist Gamma) pdf(x, lgamma float64) float64 {
   if x < 0 {
     return 0.0
   }
@@ -76,7 +126,6 @@ func (dist Gamma) Pdf(x float64) float64 {
     return math.Exp((-1 * x) * dist.Rate) * dist.Rate
   }
   first := (dist.Shape - 1) * math.Log(x * dist.Rate) - (x * dist.Rate)
-  lgamma, _ := math.Lgamma(dist.Shape)
   result := math.Exp(first - lgamma) * dist.Rate
   return result
 }
@@ -89,11 +138,16 @@ func (dist Gamma) Cdf(x float64) float64 {
   return result
 }
 
+func (dist Gamma) Quantile(p float64) float64 {
+  result := GammaIncLowerInv(dist.Shape, p) / dist.Rate
+  return result
+}
+
 // Ref: https://github.com/ampl/gsl/blob/master/randist/gamma.c
-func (dist Gamma) Random() float64 {
+func (dist Gamma) RandomWith(r *rand.Rand) float64 {
   if (dist.Shape < 1.0) {
-    random := rand.Float64()
-    grandom := Gamma{ Shape: dist.Shape + 1.0, Rate: dist.Rate }.Random()
+    random := randFloat64(r)
+    grandom := Gamma{ Shape: dist.Shape + 1.0, Rate: dist.Rate }.RandomWith(r)
     result := grandom * math.Pow(random, 1.0 / dist.Shape)
     return result
   }
@@ -102,7 +156,7 @@ func (dist Gamma) Random() float64 {
   c := 1.0 / math.Sqrt(9.0 * d)
   for {
     for {
-      random := Normal{ Mu: 0.0, Sigma: 1.0 }.Random()
+      random := Normal{ Mu: 0.0, Sigma: 1.0 }.RandomWith(r)
       x = random
       v = 1.0 + (c * x)
       if v > 0.0 {
@@ -110,7 +164,7 @@ func (dist Gamma) Random() float64 {
       }
     }
     v = v * v * v
-    u := rand.Float64()
+    u := randFloat64(r)
     if u < 1.0 - 0.0331 * x * x * x * x {
       break
     }
@@ -121,3 +175,7 @@ func (dist Gamma) Random() float64 {
   result := d * v / dist.Rate
   return result
 }
+
+func (dist Gamma) Random() float64 {
+  return dist.RandomWith(nil)
+}
@@ -56,6 +56,17 @@ func Test_Gamma(t *testing.T) {
     t.Fatal(err)
   }
 
+  quantiles := []inOut{
+    inOut{ in: 0.5,   out: 3.356693980033321 },
+    inOut{ in: 0.05,  out: 0.7107230213973239 },
+  }
+  for _, quantile := range quantiles {
+    out := Gamma{2.0, 0.5}.Quantile(quantile.in)
+    if !floatsNanoEqual(out, quantile.out) {
+      t.Fatalf("\nQuantile of %f:\n  Expected: %f\n  Got: %f\n", quantile.in, quantile.out, out)
+    }
+  }
+
   sample := Gamma{10.0, 4.0}
   if err := testSamples(sample); err != nil {
     t.Fatal(err)
@@ -66,3 +77,34 @@ func Benchmark_Gamma(b *testing.B) {
   dist := Gamma{10.0, 4.0}
   runBenchmark(b, dist)
 }
+
+func Test_Gamma_Constructors(t *testing.T) {
+  dist, err := NewGammaShapeScale(3, 0.5)
+  if err != nil || dist.Shape != 3 || dist.Rate != 2 {
+    t.Fatalf("\nShapeScale:\n  Expected: %v\n  Got: %v, %v\n", Gamma{ 3, 2 }, dist, err)
+  }
+  dist, err = NewGammaMeanVariance(6, 4)
+  if err != nil || !floatsPicoEqual(dist.Mean(), 6) || !floatsPicoEqual(dist.Variance(), 4) {
+    t.Fatalf("\nMeanVariance:\n  Expected: mean %f, variance %f\n  Got: %v, %v\n", 6.0, 4.0, dist, err)
+  }
+  if _, err := NewGammaShapeScale(3, 0); err == nil {
+    t.Fatal("\nExpected an error for a zero scale.")
+  }
+  if _, err := NewGammaShapeScale(-3, 1); err == nil {
+    t.Fatal("\nExpected an error for a negative shape.")
+  }
+  if _, err := NewGammaMeanVariance(-6, 4); err == nil {
+    t.Fatal("\nExpected an error for a negative mean.")
+  }
+  if _, err := NewGammaMeanVariance(6, 0); err == nil {
+    t.Fatal("\nExpected an error for a zero variance.")
+  }
+}
+
+func Benchmark_Gamma_Pdf(b *testing.B) {
+  runPdfBenchmark(b, Gamma{ 2.5, 1.0 }, 0, 10)
+}
+
+func Benchmark_Gamma_PdfSlice(b *testing.B) {
+  runPdfSliceBenchmark(b, Gamma{ 2.5, 1.0 }, 0, 10)
+}
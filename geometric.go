@@ -22,9 +22,9 @@ func NewGeometric(prob float64) (Geometric, error) {
   return dist, nil
 }
 
-func (dist *Geometric) Validate() error {
-  if dist.Prob <= 0 || dist.Prob > 1 {
-    return InvalidParamsError{ "Mu must be between zero and one or one." }
+func (dist Geometric) Validate() error {
+  if err := checkParam("Geometric", "Prob", dist.Prob, dist.Prob > 0 && dist.Prob <= 1, "be greater than zero and at most one"); err != nil {
+    return err
   }
   return nil
 }
@@ -63,20 +63,45 @@ func (dist Geometric) Pdf(x float64) float64 {
   if x < 0 {
     return 0.0
   }
-  result := dist.Prob * math.Pow(1 - dist.Prob, x)
+  result := dist.Prob * binomialRaw(0, math.Floor(x), dist.Prob, 1 - dist.Prob)
   return result
 }
 
+// Uses 1 - (1 - p)ᵏ⁺¹ through log1p and expm1 to keep precision for small p.
 func (dist Geometric) Cdf(x float64) float64 {
   if (x < 0.0) {
     return 0.0
   }
-  result := 1 - math.Pow(1 - dist.Prob, math.Floor(x) + 1)
+  result := -math.Expm1((math.Floor(x) + 1) * math.Log1p(-dist.Prob))
   return result
 }
 
+// Solves 1 - (1 - p)ᵏ⁺¹ = q for k, then corrects any rounding against Cdf.
+func (dist Geometric) Quantile(q float64) float64 {
+  if q < 0 || q > 1 || math.IsNaN(q) {
+    return math.NaN()
+  }
+  if q == 0 || dist.Prob == 1 {
+    return 0.0
+  }
+  if q == 1 {
+    return math.Inf(1)
+  }
+  k := math.Max(0, math.Ceil((math.Log1p(-q) / math.Log1p(-dist.Prob)) - 1))
+  if k > 0 && dist.Cdf(k - 1) >= q {
+    k--
+  } else if dist.Cdf(k) < q {
+    k++
+  }
+  return k
+}
+
 // Ref: http://math.stackexchange.com/questions/485448/prove-the-way-to-generate-geometrically-distributed-random-numbers
-func (dist Geometric) Random() float64 {
-  value := math.Floor(math.Log(rand.Float64()) / math.Log(1 - dist.Prob))
+func (dist Geometric) RandomWith(r *rand.Rand) float64 {
+  value := math.Floor(math.Log(randFloat64(r)) / math.Log(1 - dist.Prob))
   return value
 }
+
+func (dist Geometric) Random() float64 {
+  return dist.RandomWith(nil)
+}
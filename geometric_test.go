@@ -52,6 +52,16 @@ func Test_Geometric(t *testing.T) {
     t.Fatal(err)
   }
 
+  // 1 - (1 - p)¹⁰⁰¹ from its binomial expansion, which must keep its
+  // relative precision for small p.
+  tiny := Geometric{1e-10}
+  if result := tiny.Cdf(1000); !floatsPicoEqual(result / 1.0009999499500016667e-07, 1) {
+    t.Fatalf("\n  Expected: %v\n  Got: %v\n", 1.0009999499500016667e-07, result)
+  }
+  if result := tiny.Pdf(1000); !floatsPicoEqual(result / 9.99999900000004995e-11, 1) {
+    t.Fatalf("\n  Expected: %v\n  Got: %v\n", 9.99999900000004995e-11, result)
+  }
+
   sample := &Geometric{0.4}
   if err := testSamples(sample); err != nil {
     t.Fatal(err)
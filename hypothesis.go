@@ -0,0 +1,289 @@
+package prob
+
+import (
+  "math"
+)
+
+// The alternative hypothesis of a test.
+type Alternative int
+
+const (
+  TwoSided Alternative = iota
+  Less
+  Greater
+)
+
+// The outcome of a hypothesis test. Degrees is NaN for z-tests; Degrees2 is
+// only set for tests with two degrees of freedom, such as the F-test.
+// The confidence interval [Lower, Upper] for Estimate is one-sided, with an
+// infinite bound, when the alternative is.
+type TestResult struct {
+  Statistic     float64       `json:"statistic"`
+  Degrees       float64       `json:"degrees"`
+  Degrees2      float64       `json:"degrees2"`
+  PValue        float64       `json:"pValue"`
+  Alternative   Alternative   `json:"alternative"`
+  Estimate      float64       `json:"estimate"`
+  Lower         float64       `json:"lower"`
+  Upper         float64       `json:"upper"`
+  Confidence    float64       `json:"confidence"`
+}
+
+func validateAlternative(alt Alternative) error {
+  if alt < TwoSided || alt > Greater {
+    return InvalidParamsError{ S: "Alternative must be TwoSided, Less or Greater." }
+  }
+  return nil
+}
+
+func validateTest(alt Alternative, confidence float64) error {
+  if err := validateAlternative(alt); err != nil {
+    return err
+  }
+  if !(confidence > 0 && confidence < 1) {
+    return InvalidParamsError{ S: "Confidence must be between zero and one." }
+  }
+  return nil
+}
+
+// The p-value of the statistic under the given alternative from the null
+// distribution's cdf.
+func pValue(cdf func(float64) float64, statistic float64, alt Alternative) float64 {
+  lower := cdf(statistic)
+  switch alt {
+  case Less:
+    return lower
+  case Greater:
+    return 1 - lower
+  }
+  result := 2 * math.Min(lower, 1 - lower)
+  return math.Min(result, 1.0)
+}
+
+// A confidence interval estimate ± q·se, where q comes from the quantile
+// function of a symmetric pivot.
+func symmetricInterval(quantile func(float64) float64, estimate, se, confidence float64, alt Alternative) (float64, float64) {
+  switch alt {
+  case Less:
+    return math.Inf(-1), estimate + (quantile(confidence) * se)
+  case Greater:
+    return estimate - (quantile(confidence) * se), math.Inf(1)
+  }
+  q := quantile((1 + confidence) / 2)
+  return estimate - (q * se), estimate + (q * se)
+}
+
+// The mean and unbiased variance of a sample.
+func sampleMoments(x []float64) (float64, float64) {
+  var stats RunningStats
+  stats.AddAll(x)
+  return stats.Mean(), stats.SampleVariance()
+}
+
+func tTest(estimate, se, degrees, mu float64, alt Alternative, confidence float64) TestResult {
+  dist := StudentsT{ degrees }
+  statistic := (estimate - mu) / se
+  lower, upper := symmetricInterval(dist.Quantile, estimate, se, confidence, alt)
+  return TestResult{
+    Statistic:    statistic,
+    Degrees:      degrees,
+    Degrees2:     math.NaN(),
+    PValue:       pValue(dist.Cdf, statistic, alt),
+    Alternative:  alt,
+    Estimate:     estimate,
+    Lower:        lower,
+    Upper:        upper,
+    Confidence:   confidence,
+  }
+}
+
+func zTest(estimate, se, nullSe, mu float64, alt Alternative, confidence float64) TestResult {
+  dist := Normal{ 0, 1 }
+  statistic := (estimate - mu) / nullSe
+  lower, upper := symmetricInterval(dist.Quantile, estimate, se, confidence, alt)
+  return TestResult{
+    Statistic:    statistic,
+    Degrees:      math.NaN(),
+    Degrees2:     math.NaN(),
+    PValue:       pValue(dist.Cdf, statistic, alt),
+    Alternative:  alt,
+    Estimate:     estimate,
+    Lower:        lower,
+    Upper:        upper,
+    Confidence:   confidence,
+  }
+}
+
+// Student's t-test that the mean of x is mu.
+//
+// See: https://en.wikipedia.org/wiki/Student's_t-test#One-sample_t-test
+func OneSampleTTest(x []float64, mu float64, alt Alternative, confidence float64) (TestResult, error) {
+  if err := validateTest(alt, confidence); err != nil {
+    return TestResult{}, err
+  }
+  if len(x) < 2 {
+    return TestResult{}, InvalidParamsError{ S: "Samples must contain at least two values." }
+  }
+  n := float64(len(x))
+  mean, variance := sampleMoments(x)
+  result := tTest(mean, math.Sqrt(variance / n), n - 1, mu, alt, confidence)
+  return result, nil
+}
+
+// Student's t-test that the mean difference x - y of paired samples is mu.
+//
+// See: https://en.wikipedia.org/wiki/Student's_t-test#Dependent_t-test_for_paired_samples
+func PairedTTest(x, y []float64, mu float64, alt Alternative, confidence float64) (TestResult, error) {
+  if len(x) != len(y) {
+    return TestResult{}, InvalidParamsError{ S: "Paired samples must be the same length." }
+  }
+  diff := make([]float64, len(x))
+  for i := range x {
+    diff[i] = x[i] - y[i]
+  }
+  return OneSampleTTest(diff, mu, alt, confidence)
+}
+
+// Student's t-test that the difference in means of x and y is mu, assuming
+// the samples share a variance.
+//
+// See: https://en.wikipedia.org/wiki/Student's_t-test#Equal_or_unequal_sample_sizes,_similar_variances
+func PooledTTest(x, y []float64, mu float64, alt Alternative, confidence float64) (TestResult, error) {
+  if err := validateTest(alt, confidence); err != nil {
+    return TestResult{}, err
+  }
+  if len(x) < 2 || len(y) < 2 {
+    return TestResult{}, InvalidParamsError{ S: "Samples must contain at least two values." }
+  }
+  nx, ny := float64(len(x)), float64(len(y))
+  meanX, varX := sampleMoments(x)
+  meanY, varY := sampleMoments(y)
+  degrees := nx + ny - 2
+  pooled := (((nx - 1) * varX) + ((ny - 1) * varY)) / degrees
+  se := math.Sqrt(pooled * ((1 / nx) + (1 / ny)))
+  result := tTest(meanX - meanY, se, degrees, mu, alt, confidence)
+  return result, nil
+}
+
+// Welch's t-test that the difference in means of x and y is mu, without
+// assuming equal variances. Degrees of freedom come from the
+// Welch–Satterthwaite equation and are generally not whole numbers.
+//
+// See: https://en.wikipedia.org/wiki/Welch's_t-test
+func WelchTTest(x, y []float64, mu float64, alt Alternative, confidence float64) (TestResult, error) {
+  if err := validateTest(alt, confidence); err != nil {
+    return TestResult{}, err
+  }
+  if len(x) < 2 || len(y) < 2 {
+    return TestResult{}, InvalidParamsError{ S: "Samples must contain at least two values." }
+  }
+  nx, ny := float64(len(x)), float64(len(y))
+  meanX, varX := sampleMoments(x)
+  meanY, varY := sampleMoments(y)
+  vx, vy := varX / nx, varY / ny
+  degrees := (vx + vy) * (vx + vy) / ((vx * vx / (nx - 1)) + (vy * vy / (ny - 1)))
+  result := tTest(meanX - meanY, math.Sqrt(vx + vy), degrees, mu, alt, confidence)
+  return result, nil
+}
+
+// The z-test that the mean of x is mu when the population standard
+// deviation sigma is known.
+//
+// See: https://en.wikipedia.org/wiki/Z-test
+func ZTest(x []float64, mu, sigma float64, alt Alternative, confidence float64) (TestResult, error) {
+  if err := validateTest(alt, confidence); err != nil {
+    return TestResult{}, err
+  }
+  if len(x) < 1 {
+    return TestResult{}, InvalidParamsError{ S: "Samples must not be empty." }
+  }
+  if !(sigma > 0) {
+    return TestResult{}, InvalidParamsError{ S: "Sigma must be greater than zero." }
+  }
+  mean, _ := sampleMoments(x)
+  se := sigma / math.Sqrt(float64(len(x)))
+  result := zTest(mean, se, se, mu, alt, confidence)
+  return result, nil
+}
+
+// The z-test that the proportion of successes in trials is p. The statistic
+// uses the standard error under the null; the interval is the Wald interval.
+//
+// See: https://en.wikipedia.org/wiki/Binomial_proportion_confidence_interval#Normal_approximation_interval
+func OneProportionZTest(successes, trials, p float64, alt Alternative, confidence float64) (TestResult, error) {
+  if err := validateTest(alt, confidence); err != nil {
+    return TestResult{}, err
+  }
+  if !(trials > 0) || successes < 0 || successes > trials {
+    return TestResult{}, InvalidParamsError{ S: "Successes must be between zero and Trials." }
+  }
+  if !(p > 0 && p < 1) {
+    return TestResult{}, InvalidParamsError{ S: "Prob must be between zero and one." }
+  }
+  estimate := successes / trials
+  se := math.Sqrt(estimate * (1 - estimate) / trials)
+  nullSe := math.Sqrt(p * (1 - p) / trials)
+  result := zTest(estimate, se, nullSe, p, alt, confidence)
+  return result, nil
+}
+
+// The z-test that two proportions are equal. The statistic uses the pooled
+// proportion; the interval for the difference is the unpooled Wald interval.
+//
+// See: https://en.wikipedia.org/wiki/Two-proportion_Z-test
+func TwoProportionZTest(successes1, trials1, successes2, trials2 float64, alt Alternative, confidence float64) (TestResult, error) {
+  if err := validateTest(alt, confidence); err != nil {
+    return TestResult{}, err
+  }
+  if !(trials1 > 0) || successes1 < 0 || successes1 > trials1 || !(trials2 > 0) || successes2 < 0 || successes2 > trials2 {
+    return TestResult{}, InvalidParamsError{ S: "Successes must be between zero and Trials." }
+  }
+  p1, p2 := successes1 / trials1, successes2 / trials2
+  pooled := (successes1 + successes2) / (trials1 + trials2)
+  se := math.Sqrt((p1 * (1 - p1) / trials1) + (p2 * (1 - p2) / trials2))
+  nullSe := math.Sqrt(pooled * (1 - pooled) * ((1 / trials1) + (1 / trials2)))
+  result := zTest(p1 - p2, se, nullSe, 0, alt, confidence)
+  return result, nil
+}
+
+// The F-test that the ratio of the variances of x and y is ratio.
+//
+// See: https://en.wikipedia.org/wiki/F-test_of_equality_of_variances
+func FTest(x, y []float64, ratio float64, alt Alternative, confidence float64) (TestResult, error) {
+  if err := validateTest(alt, confidence); err != nil {
+    return TestResult{}, err
+  }
+  if len(x) < 2 || len(y) < 2 {
+    return TestResult{}, InvalidParamsError{ S: "Samples must contain at least two values." }
+  }
+  if !(ratio > 0) {
+    return TestResult{}, InvalidParamsError{ S: "Ratio must be greater than zero." }
+  }
+  d1, d2 := float64(len(x) - 1), float64(len(y) - 1)
+  _, varX := sampleMoments(x)
+  _, varY := sampleMoments(y)
+  estimate := varX / varY
+  statistic := estimate / ratio
+  dist := F{ d1, d2 }
+  lower, upper := 0.0, math.Inf(1)
+  switch alt {
+  case Less:
+    upper = estimate / dist.Quantile(1 - confidence)
+  case Greater:
+    lower = estimate / dist.Quantile(confidence)
+  default:
+    lower = estimate / dist.Quantile((1 + confidence) / 2)
+    upper = estimate / dist.Quantile((1 - confidence) / 2)
+  }
+  return TestResult{
+    Statistic:    statistic,
+    Degrees:      d1,
+    Degrees2:     d2,
+    PValue:       pValue(dist.Cdf, statistic, alt),
+    Alternative:  alt,
+    Estimate:     estimate,
+    Lower:        lower,
+    Upper:        upper,
+    Confidence:   confidence,
+  }, nil
+}
@@ -0,0 +1,141 @@
+package prob
+
+import (
+  "fmt"
+  "math"
+  "testing"
+)
+
+// Student's sleep data, as in R's datasets::sleep.
+var (
+  sleepGroup1 = []float64{ 0.7, -1.6, -0.2, -1.2, -0.1, 3.4, 3.7, 0.8, 0.0, 2.0 }
+  sleepGroup2 = []float64{ 1.9, 0.8, 1.1, 0.1, -0.1, 4.4, 5.5, 1.6, 4.6, 3.4 }
+)
+
+func checkTestResult(result TestResult, err error, expected TestResult) error {
+  if err != nil {
+    return err
+  }
+  pairs := []struct{ name string; got, expected float64 }{
+    { "Statistic",  result.Statistic,   expected.Statistic },
+    { "Degrees",    result.Degrees,     expected.Degrees },
+    { "Degrees2",   result.Degrees2,    expected.Degrees2 },
+    { "PValue",     result.PValue,      expected.PValue },
+    { "Lower",      result.Lower,       expected.Lower },
+    { "Upper",      result.Upper,       expected.Upper },
+  }
+  for _, pair := range pairs {
+    if !floatsNanoEqual(pair.got, pair.expected) {
+      if !checkInf(pair.got, pair.expected) && !checkNaN(pair.got, pair.expected) {
+        return fmt.Errorf("\n%s:\n  Expected: %f\n  Got: %f\n", pair.name, pair.expected, pair.got)
+      }
+    }
+  }
+  return nil
+}
+
+// Test against R's t.test, var.test and prop.test(correct = FALSE).
+func Test_Hypothesis_TTest(t *testing.T) {
+  nan := math.NaN()
+  result, err := OneSampleTTest(sleepGroup1, 0, TwoSided, 0.95)
+  if err := checkTestResult(result, err, TestResult{
+    Statistic: 1.3257101407138212, Degrees: 9, Degrees2: nan, PValue: 0.2175977800684501,
+    Lower: -0.5297804135262434, Upper: 2.029780413526243,
+  }); err != nil {
+    t.Fatal(err)
+  }
+  result, err = PairedTTest(sleepGroup1, sleepGroup2, 0, TwoSided, 0.95)
+  if err := checkTestResult(result, err, TestResult{
+    Statistic: -4.062127683382037, Degrees: 9, Degrees2: nan, PValue: 0.0028328901973860843,
+    Lower: -2.4598857632769904, Upper: -0.7001142367230098,
+  }); err != nil {
+    t.Fatal(err)
+  }
+  result, err = PairedTTest(sleepGroup1, sleepGroup2, 0, Less, 0.95)
+  if err := checkTestResult(result, err, TestResult{
+    Statistic: -4.062127683382037, Degrees: 9, Degrees2: nan, PValue: 0.0014164450986930421,
+    Lower: math.Inf(-1), Upper: -0.8669947329707127,
+  }); err != nil {
+    t.Fatal(err)
+  }
+  result, err = PairedTTest(sleepGroup1, sleepGroup2, 0, Greater, 0.95)
+  if err != nil || !floatsNanoEqual(result.PValue, 0.9985835549013069) {
+    t.Fatalf("\nPValue:\n  Expected: %f\n  Got: %f\n", 0.9985835549013069, result.PValue)
+  }
+  result, err = PooledTTest(sleepGroup1, sleepGroup2, 0, TwoSided, 0.95)
+  if err := checkTestResult(result, err, TestResult{
+    Statistic: -1.8608134674868526, Degrees: 18, Degrees2: nan, PValue: 0.0791867142159366,
+    Lower: -3.363874032287594, Upper: 0.2038740322875947,
+  }); err != nil {
+    t.Fatal(err)
+  }
+  result, err = WelchTTest(sleepGroup1, sleepGroup2, 0, TwoSided, 0.95)
+  if err := checkTestResult(result, err, TestResult{
+    Statistic: -1.8608134674868526, Degrees: 17.776473516178495, Degrees2: nan, PValue: 0.07939414018735691,
+    Lower: -3.3654832307117015, Upper: 0.20548323071170227,
+  }); err != nil {
+    t.Fatal(err)
+  }
+  // Unequal sizes and a one-sided alternative, against Simpson's rule on
+  // the density for the fractional degrees of freedom.
+  longer := append(append([]float64{}, sleepGroup2...), 2.2, 6.1, 0.4)
+  result, err = WelchTTest(sleepGroup1, longer, 0, Less, 0.95)
+  if err := checkTestResult(result, err, TestResult{
+    Statistic: -2.098828829985282, Degrees: 20.764361122263093, Degrees2: nan, PValue: 0.024122406028060484,
+    Lower: math.Inf(-1), Upper: -0.3075844076959162,
+  }); err != nil {
+    t.Fatal(err)
+  }
+
+  if _, err := OneSampleTTest([]float64{ 1.0 }, 0, TwoSided, 0.95); err == nil {
+    t.Fatal("\nExpected an error for a single sample.")
+  }
+  if _, err := PairedTTest(sleepGroup1, sleepGroup2[1:], 0, TwoSided, 0.95); err == nil {
+    t.Fatal("\nExpected an error for unpaired samples.")
+  }
+  if _, err := PooledTTest(sleepGroup1, sleepGroup2, 0, TwoSided, 1.5); err == nil {
+    t.Fatal("\nExpected an error for an invalid confidence.")
+  }
+}
+
+func Test_Hypothesis_ZTest(t *testing.T) {
+  nan := math.NaN()
+  result, err := ZTest(sleepGroup1, 0.5, 1.8, TwoSided, 0.95)
+  if err := checkTestResult(result, err, TestResult{
+    Statistic: 0.4392052305789416, Degrees: nan, Degrees2: nan, PValue: 0.6605128353820287,
+    Lower: -0.3656310581482105, Upper: 1.8656310581482105,
+  }); err != nil {
+    t.Fatal(err)
+  }
+  result, err = OneProportionZTest(52, 100, 0.4, TwoSided, 0.95)
+  if err := checkTestResult(result, err, TestResult{
+    Statistic: 2.4494897427831783, Degrees: nan, Degrees2: nan, PValue: 0.01430587843542952,
+    Lower: 0.42208023071691536, Upper: 0.6179197692830847,
+  }); err != nil {
+    t.Fatal(err)
+  }
+  result, err = OneProportionZTest(52, 100, 0.4, Greater, 0.95)
+  if err != nil || !floatsNanoEqual(result.PValue, 0.00715293921771476) {
+    t.Fatalf("\nPValue:\n  Expected: %f\n  Got: %f\n", 0.00715293921771476, result.PValue)
+  }
+  result, err = TwoProportionZTest(45, 120, 30, 110, TwoSided, 0.95)
+  if err := checkTestResult(result, err, TestResult{
+    Statistic: 1.6527769221078203, Degrees: nan, Degrees2: nan, PValue: 0.09837627253195214,
+    Lower: -0.017850530029377226, Upper: 0.2223959845748318,
+  }); err != nil {
+    t.Fatal(err)
+  }
+  if _, err := OneProportionZTest(120, 100, 0.4, TwoSided, 0.95); err == nil {
+    t.Fatal("\nExpected an error for more successes than trials.")
+  }
+}
+
+func Test_Hypothesis_FTest(t *testing.T) {
+  result, err := FTest(sleepGroup1, sleepGroup2, 1, TwoSided, 0.95)
+  if err := checkTestResult(result, err, TestResult{
+    Statistic: 0.7983426179983927, Degrees: 9, Degrees2: 9, PValue: 0.7427199317260447,
+    Lower: 0.19829701351053436, Upper: 3.2141227163698605,
+  }); err != nil {
+    t.Fatal(err)
+  }
+}
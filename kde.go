@@ -0,0 +1,391 @@
+package prob
+
+import (
+  "math"
+  "math/rand"
+  "sort"
+)
+
+const (
+  kde_cv_iterations = 100
+  kde_cv_tolerance = 1e-4
+)
+
+// A smoothing kernel for kernel density estimation. Every kernel is a
+// symmetric density with mean zero; all but the Gaussian are supported on
+// [-1, 1].
+//
+// See: https://en.wikipedia.org/wiki/Kernel_(statistics)
+type Kernel int
+
+const (
+  GaussianKernel Kernel = iota
+  EpanechnikovKernel
+  UniformKernel
+  TriangularKernel
+  BiweightKernel
+  TriweightKernel
+)
+
+func (k Kernel) valid() bool {
+  return k >= GaussianKernel && k <= TriweightKernel
+}
+
+func (k Kernel) pdf(u float64) float64 {
+  if k == GaussianKernel {
+    return math.Exp(-u * u / 2) / math.Sqrt(2 * math.Pi)
+  }
+  if u < -1 || u > 1 {
+    return 0.0
+  }
+  v := 1 - (u * u)
+  switch k {
+  case EpanechnikovKernel:
+    return 0.75 * v
+  case UniformKernel:
+    return 0.5
+  case TriangularKernel:
+    return 1 - math.Abs(u)
+  case BiweightKernel:
+    return 15.0 / 16.0 * v * v
+  case TriweightKernel:
+    return 35.0 / 32.0 * v * v * v
+  }
+  return math.NaN()
+}
+
+func (k Kernel) cdf(u float64) float64 {
+  if k == GaussianKernel {
+    return 0.5 * (1 + math.Erf(u / math.Sqrt2))
+  }
+  if u <= -1 {
+    return 0.0
+  }
+  if u >= 1 {
+    return 1.0
+  }
+  u2 := u * u
+  switch k {
+  case EpanechnikovKernel:
+    return 0.5 + (0.75 * u) - (0.25 * u * u2)
+  case UniformKernel:
+    return (u + 1) / 2
+  case TriangularKernel:
+    if u < 0 {
+      return (1 + u) * (1 + u) / 2
+    }
+    return 1 - ((1 - u) * (1 - u) / 2)
+  case BiweightKernel:
+    return 0.5 + (15.0 / 16.0 * u * (1 - (2 * u2 / 3) + (u2 * u2 / 5)))
+  case TriweightKernel:
+    return 0.5 + (35.0 / 32.0 * u * (1 - u2 + (3 * u2 * u2 / 5) - (u2 * u2 * u2 / 7)))
+  }
+  return math.NaN()
+}
+
+// The second and fourth moments of the kernel.
+func (k Kernel) moments() (float64, float64) {
+  switch k {
+  case GaussianKernel:
+    return 1.0, 3.0
+  case EpanechnikovKernel:
+    return 1.0 / 5.0, 3.0 / 35.0
+  case UniformKernel:
+    return 1.0 / 3.0, 1.0 / 5.0
+  case TriangularKernel:
+    return 1.0 / 6.0, 1.0 / 15.0
+  case BiweightKernel:
+    return 1.0 / 7.0, 1.0 / 21.0
+  case TriweightKernel:
+    return 1.0 / 9.0, 1.0 / 33.0
+  }
+  return math.NaN(), math.NaN()
+}
+
+// The integral of the squared kernel.
+func (k Kernel) roughness() float64 {
+  switch k {
+  case GaussianKernel:
+    return 1 / (2 * math.Sqrt(math.Pi))
+  case EpanechnikovKernel:
+    return 3.0 / 5.0
+  case UniformKernel:
+    return 1.0 / 2.0
+  case TriangularKernel:
+    return 2.0 / 3.0
+  case BiweightKernel:
+    return 5.0 / 7.0
+  case TriweightKernel:
+    return 350.0 / 429.0
+  }
+  return math.NaN()
+}
+
+// Scales a bandwidth chosen for the Gaussian kernel to an equivalent one for
+// this kernel using canonical bandwidths.
+// Ref: Marron & Nolan (1988), Canonical kernels for density estimation.
+func (k Kernel) gaussianScale() float64 {
+  canonical := func(k Kernel) float64 {
+    variance, _ := k.moments()
+    return math.Pow(k.roughness() / (variance * variance), 0.2)
+  }
+  return canonical(k) / canonical(GaussianKernel)
+}
+
+// The polynomial kernels are affine transforms of symmetric Beta variates.
+func (k Kernel) random(r *rand.Rand) float64 {
+  switch k {
+  case GaussianKernel:
+    return Normal{ Mu: 0, Sigma: 1 }.RandomWith(r)
+  case EpanechnikovKernel:
+    return (2 * Beta{ Alpha: 2, Beta: 2 }.RandomWith(r)) - 1
+  case UniformKernel:
+    return (2 * randFloat64(r)) - 1
+  case TriangularKernel:
+    return randFloat64(r) + randFloat64(r) - 1
+  case BiweightKernel:
+    return (2 * Beta{ Alpha: 3, Beta: 3 }.RandomWith(r)) - 1
+  case TriweightKernel:
+    return (2 * Beta{ Alpha: 4, Beta: 4 }.RandomWith(r)) - 1
+  }
+  return math.NaN()
+}
+
+//The KDE Distribution is a continuous probability distribution estimated
+// from samples by kernel density estimation with a given kernel and
+// bandwidth h > 0.
+//
+// See: https://en.wikipedia.org/wiki/Kernel_density_estimation
+type KDE struct {
+  Samples     []float64   `json:"samples"`
+  Kernel      Kernel      `json:"kernel"`
+  Bandwidth   float64     `json:"bandwidth"`
+}
+
+// The samples are copied and sorted. Use SilvermanBandwidth, ScottBandwidth
+// or CrossValidationBandwidth to pick the bandwidth.
+func NewKDE(samples []float64, kernel Kernel, bandwidth float64) (KDE, error) {
+  sorted := make([]float64, len(samples))
+  copy(sorted, samples)
+  sort.Float64s(sorted)
+  dist := KDE{ sorted, kernel, bandwidth }
+  if err := dist.Validate(); err != nil {
+    return dist, err
+  }
+  return dist, nil
+}
+
+func (dist KDE) Validate() error {
+  if err := (Empirical{ Values: dist.Samples }).Validate(); err != nil {
+    samples := err.(InvalidParamsError)
+    samples.Dist, samples.Param = "KDE", "Samples"
+    return samples
+  }
+  if !dist.Kernel.valid() {
+    return InvalidParamsError{ Dist: "KDE", Param: "Kernel", Value: float64(dist.Kernel), Constraint: "be one of the defined kernels" }
+  }
+  if err := checkPositive("KDE", "Bandwidth", dist.Bandwidth); err != nil {
+    return err
+  }
+  return nil
+}
+
+func (dist KDE) Mean() float64 {
+  return Empirical{ Values: dist.Samples }.Mean()
+}
+
+func (dist KDE) Variance() float64 {
+  variance, _ := dist.Kernel.moments()
+  sample := Empirical{ Values: dist.Samples }.Variance()
+  result := sample + (dist.Bandwidth * dist.Bandwidth * variance)
+  return result
+}
+
+func (dist KDE) Skewness() float64 {
+  third := Empirical{ Values: dist.Samples }.moment(3)
+  result := third / math.Pow(dist.Variance(), 1.5)
+  return result
+}
+
+// Returns the excess kurtosis.
+func (dist KDE) Kurtosis() float64 {
+  sample := Empirical{ Values: dist.Samples }
+  second, fourth := dist.Kernel.moments()
+  h2 := dist.Bandwidth * dist.Bandwidth
+  m4 := sample.moment(4) + (6 * sample.Variance() * h2 * second) + (h2 * h2 * fourth)
+  variance := dist.Variance()
+  result := m4 / (variance * variance) - 3
+  return result
+}
+
+func (dist KDE) StdDev() float64 {
+  variance := dist.Variance()
+  result := math.Sqrt(variance)
+  return result
+}
+
+func (dist KDE) RelStdDev() float64 {
+  mean := dist.Mean()
+  stdDev := dist.StdDev()
+  result := stdDev / mean
+  return result
+}
+
+func (dist KDE) Pdf(x float64) float64 {
+  sum := 0.0
+  for _, sample := range dist.Samples {
+    sum += dist.Kernel.pdf((x - sample) / dist.Bandwidth)
+  }
+  result := sum / (float64(len(dist.Samples)) * dist.Bandwidth)
+  return result
+}
+
+func (dist KDE) Cdf(x float64) float64 {
+  sum := 0.0
+  for _, sample := range dist.Samples {
+    sum += dist.Kernel.cdf((x - sample) / dist.Bandwidth)
+  }
+  result := sum / float64(len(dist.Samples))
+  return result
+}
+
+// The Gaussian kernel has unbounded support; the others end one bandwidth
+// past the outermost samples.
+func (dist KDE) Quantile(p float64) float64 {
+  if p < 0 || p > 1 || math.IsNaN(p) {
+    return math.NaN()
+  }
+  if p == 0 || p == 1 {
+    if dist.Kernel == GaussianKernel {
+      return math.Inf(int(2 * p) - 1)
+    }
+    if p == 0 {
+      return dist.Samples[0] - dist.Bandwidth
+    }
+    return dist.Samples[len(dist.Samples) - 1] + dist.Bandwidth
+  }
+  mean, stdDev := dist.Mean(), dist.StdDev()
+  result := inverseCdf(dist.Cdf, p, mean - stdDev, mean + stdDev)
+  return result
+}
+
+// Resamples one of the samples and adds kernel noise scaled by the bandwidth.
+func (dist KDE) RandomWith(r *rand.Rand) float64 {
+  sample := dist.Samples[randIntn(r, len(dist.Samples))]
+  if dist.Kernel == GaussianKernel {
+    return Normal{ Mu: sample, Sigma: dist.Bandwidth }.RandomWith(r)
+  }
+  value := sample + (dist.Bandwidth * dist.Kernel.random(r))
+  return value
+}
+
+func (dist KDE) Random() float64 {
+  return dist.RandomWith(nil)
+}
+
+// Silverman's rule of thumb, 0.9 min(σ, IQR/1.34) n^(-1/5), scaled to the
+// kernel. Returns NaN for fewer than two samples.
+//
+// See: https://en.wikipedia.org/wiki/Kernel_density_estimation#A_rule-of-thumb_bandwidth_estimator
+func SilvermanBandwidth(samples []float64, kernel Kernel) float64 {
+  n := float64(len(samples))
+  if n < 2 {
+    return math.NaN()
+  }
+  sample, err := NewEmpirical(samples)
+  if err != nil {
+    return math.NaN()
+  }
+  spread := math.Sqrt(sample.Variance() * n / (n - 1))
+  iqr := (sample.Quantile(0.75) - sample.Quantile(0.25)) / 1.34
+  if iqr > 0 && iqr < spread {
+    spread = iqr
+  }
+  result := 0.9 * spread * math.Pow(n, -0.2) * kernel.gaussianScale()
+  return result
+}
+
+// Scott's rule of thumb, 1.06 σ n^(-1/5), scaled to the kernel. Returns NaN
+// for fewer than two samples.
+func ScottBandwidth(samples []float64, kernel Kernel) float64 {
+  n := float64(len(samples))
+  if n < 2 {
+    return math.NaN()
+  }
+  sample, err := NewEmpirical(samples)
+  if err != nil {
+    return math.NaN()
+  }
+  spread := math.Sqrt(sample.Variance() * n / (n - 1))
+  result := 1.06 * spread * math.Pow(n, -0.2) * kernel.gaussianScale()
+  return result
+}
+
+// Chooses the bandwidth maximizing the leave-one-out log likelihood. The
+// search starts from Silverman's rule, doubles its bracket towards the higher
+// likelihood until it falls off on both sides and then narrows it by golden
+// section. Each step is O(n^2). Returns NaN when the bracket never closes,
+// as when the likelihood grows without bound for repeated samples.
+//
+// See: https://en.wikipedia.org/wiki/Kernel_density_estimation#Bandwidth_selection
+func CrossValidationBandwidth(samples []float64, kernel Kernel) float64 {
+  guess := SilvermanBandwidth(samples, kernel)
+  if math.IsNaN(guess) || guess <= 0 {
+    return math.NaN()
+  }
+  n := float64(len(samples))
+  score := func(logh float64) float64 {
+    h := math.Exp(logh)
+    total := 0.0
+    for i, xi := range samples {
+      sum := 0.0
+      for j, xj := range samples {
+        if i != j {
+          sum += kernel.pdf((xi - xj) / h)
+        }
+      }
+      total += math.Log(sum / ((n - 1) * h))
+    }
+    return total
+  }
+  lo, mid, hi := math.Log(guess / 10), math.Log(guess), math.Log(guess * 4)
+  flo, fmid, fhi := score(lo), score(mid), score(hi)
+  for i := 0; flo >= fmid || fhi >= fmid; i++ {
+    if i == kde_cv_iterations {
+      return math.NaN()
+    }
+    // Compact kernels score -Inf below the largest gap to a neighbor, so
+    // ties go towards wider bandwidths.
+    if flo > fhi {
+      hi, fhi, mid, fmid = mid, fmid, lo, flo
+      lo = mid - (2 * (hi - mid))
+      flo = score(lo)
+    } else {
+      lo, flo, mid, fmid = mid, fmid, hi, fhi
+      hi = mid + (2 * (mid - lo))
+      fhi = score(hi)
+    }
+  }
+  // A bandwidth that underflows to zero scores NaN, which stops the widening
+  // without a maximum.
+  if math.IsNaN(flo) || math.IsNaN(fhi) {
+    return math.NaN()
+  }
+  ratio := (math.Sqrt(5) - 1) / 2
+  a := hi - (ratio * (hi - lo))
+  b := lo + (ratio * (hi - lo))
+  fa, fb := score(a), score(b)
+  for i := 0; i < kde_cv_iterations && hi - lo > kde_cv_tolerance; i++ {
+    if fa < fb || math.IsInf(fa, -1) {
+      lo, a, fa = a, b, fb
+      b = lo + (ratio * (hi - lo))
+      fb = score(b)
+    } else {
+      hi, b, fb = b, a, fa
+      a = hi - (ratio * (hi - lo))
+      fa = score(a)
+    }
+  }
+  result := math.Exp((lo + hi) / 2)
+  return result
+}
@@ -23,8 +23,11 @@ func NewLogistic(location float64, scale float64) (Logistic, error) {
 }
 
 func (dist Logistic) Validate() error {
-  if dist.Scale <= 0 {
-    return InvalidParamsError{ "Scale must be greater than zero." }
+  if err := checkFinite("Logistic", "Location", dist.Location); err != nil {
+    return err
+  }
+  if err := checkPositive("Logistic", "Scale", dist.Scale); err != nil {
+    return err
   }
   return nil
 }
@@ -69,9 +72,21 @@ func (dist Logistic) Cdf(x float64) float64 {
   return result
 }
 
+func (dist Logistic) Quantile(p float64) float64 {
+  if p < 0 || p > 1 || math.IsNaN(p) {
+    return math.NaN()
+  }
+  result := dist.Location + (dist.Scale * math.Log(p / (1 - p)))
+  return result
+}
+
 // Ref: http://www.stata.com/statalist/archive/2005-08/msg00131.html
-func (dist Logistic) Random() float64 {
-  u := rand.Float64()
+func (dist Logistic) RandomWith(r *rand.Rand) float64 {
+  u := randFloat64(r)
   value := dist.Location - (dist.Scale * math.Log((1 / u) - 1))
   return value
 }
+
+func (dist Logistic) Random() float64 {
+  return dist.RandomWith(nil)
+}
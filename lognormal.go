@@ -2,6 +2,7 @@ package prob
 
 import (
   "math"
+  "math/rand"
 )
 
 //The Log-Normal Distribution is a continuous probability distribution
@@ -21,9 +22,25 @@ func NewLogNormal(mu float64, sigma float64) (LogNormal, error) {
   return dist, nil
 }
 
+// The log-normal distribution with the given mean and variance, using
+// σ² = ln(1 + variance / mean²) and μ = ln(mean) - σ² / 2.
+func NewLogNormalFromMoments(mean, variance float64) (LogNormal, error) {
+  if err := checkPositive("LogNormal", "Mean", mean); err != nil {
+    return LogNormal{}, err
+  }
+  if err := checkNonNegative("LogNormal", "Variance", variance); err != nil {
+    return LogNormal{}, err
+  }
+  sigma2 := math.Log1p(variance / (mean * mean))
+  return NewLogNormal(math.Log(mean) - (sigma2 / 2), math.Sqrt(sigma2))
+}
+
 func (dist LogNormal) Validate() error {
-  if dist.Sigma < 0 {
-    return InvalidParamsError{ "Sigma must be greater than zero." }
+  if err := checkFinite("LogNormal", "Mu", dist.Mu); err != nil {
+    return err
+  }
+  if err := checkNonNegative("LogNormal", "Sigma", dist.Sigma); err != nil {
+    return err
   }
   return nil
 }
@@ -80,9 +97,21 @@ func (dist LogNormal) Cdf(x float64) float64 {
   return result
 }
 
+func (dist LogNormal) Quantile(p float64) float64 {
+  if p < 0 || p > 1 || math.IsNaN(p) {
+    return math.NaN()
+  }
+  result := math.Exp(dist.Mu + (dist.Sigma * math.Sqrt2 * math.Erfinv((2 * p) - 1)))
+  return result
+}
+
 // A lognormal random variate is e^Normal{mu, sigma}.
-func (dist LogNormal) Random() float64 {
-  random := Normal{ Mu: dist.Mu, Sigma: dist.Sigma }.Random()
+func (dist LogNormal) RandomWith(r *rand.Rand) float64 {
+  random := Normal{ Mu: dist.Mu, Sigma: dist.Sigma }.RandomWith(r)
   value := math.Exp(random)
   return value
 }
+
+func (dist LogNormal) Random() float64 {
+  return dist.RandomWith(nil)
+}
@@ -60,9 +60,9 @@ func Test_LogNormal(t *testing.T) {
 
 // This computes and compares parameters to MLE results.
 func estimateLogNormal(dist LogNormal) error {
-  samples := Sample(dist, numSamples)
-  if len(samples) != numSamples {
-    return fmt.Errorf("\nCould not generate samples.")
+  samples, err := Sample(dist, numSamples)
+  if err != nil || len(samples) != numSamples {
+    return fmt.Errorf("\nCould not generate samples: %v", err)
   }
   n := float64(numSamples)
   sum := 0.0
@@ -89,3 +89,22 @@ func Benchmark_LogNormal(b *testing.B) {
   dist := LogNormal{10.0, 4.0}
   runBenchmark(b, dist)
 }
+
+func Test_LogNormal_Constructors(t *testing.T) {
+  dist, err := NewLogNormalFromMoments(3, 2)
+  if err != nil || !floatsPicoEqual(dist.Mean(), 3) || !floatsPicoEqual(dist.Variance(), 2) {
+    t.Fatalf("\nFromMoments:\n  Expected: mean %f, variance %f\n  Got: %v, %v\n", 3.0, 2.0, dist, err)
+  }
+  // The moments of LogNormal{0.5, 0.75} round trip to the same parameters.
+  source := LogNormal{ 0.5, 0.75 }
+  dist, err = NewLogNormalFromMoments(source.Mean(), source.Variance())
+  if err != nil || !floatsPicoEqual(dist.Mu, 0.5) || !floatsPicoEqual(dist.Sigma, 0.75) {
+    t.Fatalf("\nFromMoments:\n  Expected: %v\n  Got: %v, %v\n", source, dist, err)
+  }
+  if _, err := NewLogNormalFromMoments(0, 2); err == nil {
+    t.Fatal("\nExpected an error for a zero mean.")
+  }
+  if _, err := NewLogNormalFromMoments(3, -2); err == nil {
+    t.Fatal("\nExpected an error for a negative variance.")
+  }
+}
@@ -0,0 +1,104 @@
+package prob
+
+import (
+  "math"
+  "sort"
+)
+
+// A method of adjusting p-values for multiple comparisons.
+type Correction int
+
+const (
+  // Controls the family-wise error rate by multiplying by the number of tests.
+  Bonferroni Correction = iota
+  // Holm's step-down form of Bonferroni, which is uniformly more powerful.
+  Holm
+  // Hochberg's step-up procedure, valid for independent tests.
+  Hochberg
+  // Controls the false discovery rate for independent or positively
+  // dependent tests.
+  BenjaminiHochberg
+  // Controls the false discovery rate under any dependence.
+  BenjaminiYekutieli
+)
+
+// The p-values adjusted by the correction, in their original order, and
+// whether each null hypothesis is rejected at level alpha after adjustment.
+// The adjustments match R's p.adjust.
+//
+// See: https://en.wikipedia.org/wiki/Multiple_comparisons_problem
+func CorrectPValues(pValues []float64, method Correction, alpha float64) ([]float64, []bool, error) {
+  if method < Bonferroni || method > BenjaminiYekutieli {
+    return nil, nil, InvalidParamsError{ S: "Correction must be Bonferroni, Holm, Hochberg, BenjaminiHochberg or BenjaminiYekutieli." }
+  }
+  if !(alpha > 0 && alpha < 1) {
+    return nil, nil, InvalidParamsError{ S: "Alpha must be between zero and one." }
+  }
+  for _, p := range pValues {
+    if !(p >= 0 && p <= 1) {
+      return nil, nil, InvalidParamsError{ S: "PValues must be between zero and one." }
+    }
+  }
+  n := float64(len(pValues))
+  order := make([]int, len(pValues))
+  for i := range order {
+    order[i] = i
+  }
+  sort.SliceStable(order, func(i, j int) bool { return pValues[order[i]] < pValues[order[j]] })
+  adjusted := make([]float64, len(pValues))
+  switch method {
+  case Bonferroni:
+    for i, p := range pValues {
+      adjusted[i] = math.Min(n * p, 1.0)
+    }
+  case Holm:
+    // Step down from the smallest p-value, keeping the adjustment monotone.
+    running := 0.0
+    for rank, i := range order {
+      running = math.Max(running, (n - float64(rank)) * pValues[i])
+      adjusted[i] = math.Min(running, 1.0)
+    }
+  default:
+    // Step up from the largest p-value.
+    scale := 1.0
+    if method == BenjaminiYekutieli {
+      scale = 0.0
+      for k := 1.0; k <= n; k++ {
+        scale += 1 / k
+      }
+    }
+    running := math.Inf(1)
+    for rank := len(order) - 1; rank >= 0; rank-- {
+      i := order[rank]
+      factor := n - float64(rank)
+      if method != Hochberg {
+        factor = scale * n / float64(rank + 1)
+      }
+      running = math.Min(running, factor * pValues[i])
+      adjusted[i] = math.Min(running, 1.0)
+    }
+  }
+  reject := make([]bool, len(pValues))
+  for i, p := range adjusted {
+    reject[i] = p <= alpha
+  }
+  return adjusted, reject, nil
+}
+
+// Applies CorrectPValues to the results of a batch of tests, returning copies
+// whose PValue is adjusted.
+func CorrectResults(results []TestResult, method Correction, alpha float64) ([]TestResult, []bool, error) {
+  pValues := make([]float64, len(results))
+  for i, result := range results {
+    pValues[i] = result.PValue
+  }
+  adjusted, reject, err := CorrectPValues(pValues, method, alpha)
+  if err != nil {
+    return nil, nil, err
+  }
+  corrected := append([]TestResult{}, results...)
+  for i := range corrected {
+    corrected[i].PValue = adjusted[i]
+  }
+  return corrected, reject, nil
+}
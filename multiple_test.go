@@ -0,0 +1,78 @@
+package prob
+
+import (
+  "testing"
+)
+
+// Test against R's p.adjust.
+func Test_CorrectPValues(t *testing.T) {
+  pValues := []float64{ 0.01, 0.04, 0.03, 0.005, 0.2, 0.04, 0.5, 0.001 }
+  examples := []struct{
+    method    Correction
+    adjusted  []float64
+  }{
+    { Bonferroni,          []float64{ 0.08, 0.32, 0.24, 0.04, 1, 0.32, 1, 0.008 } },
+    { Holm,                []float64{ 0.06, 0.16, 0.15, 0.035, 0.4, 0.16, 0.5, 0.008 } },
+    { Hochberg,            []float64{ 0.06, 0.12, 0.12, 0.035, 0.4, 0.12, 0.5, 0.008 } },
+    { BenjaminiHochberg,   []float64{ 0.026666666666666665, 0.05333333333333333, 0.05333333333333333, 0.02, 0.22857142857142856, 0.05333333333333333, 0.5, 0.008 } },
+    { BenjaminiYekutieli,  []float64{ 0.07247619047619047, 0.14495238095238094, 0.14495238095238094, 0.054357142857142854, 0.6212244897959183, 0.14495238095238094, 1, 0.02174285714285714 } },
+  }
+  for _, example := range examples {
+    adjusted, reject, err := CorrectPValues(pValues, example.method, 0.05)
+    if err != nil {
+      t.Fatal(err)
+    }
+    for i := range adjusted {
+      if !floatsPicoEqual(adjusted[i], example.adjusted[i]) {
+        t.Fatalf("\nAdjusted %d with %d:\n  Expected: %f\n  Got: %f\n", i, example.method, example.adjusted[i], adjusted[i])
+      }
+      if reject[i] != (example.adjusted[i] <= 0.05) {
+        t.Fatalf("\nReject %d with %d:\n  Expected: %t\n  Got: %t\n", i, example.method, !reject[i], reject[i])
+      }
+    }
+  }
+
+  if _, _, err := CorrectPValues([]float64{ 0.5, 1.5 }, Holm, 0.05); err == nil {
+    t.Fatal("\nExpected an error for a p-value above one.")
+  }
+  if _, _, err := CorrectPValues(pValues, Correction(7), 0.05); err == nil {
+    t.Fatal("\nExpected an error for an unknown correction.")
+  }
+  if _, _, err := CorrectPValues(pValues, Holm, 0); err == nil {
+    t.Fatal("\nExpected an error for a zero alpha.")
+  }
+}
+
+func Test_CorrectResults(t *testing.T) {
+  results := []TestResult{}
+  for _, run := range []func() (TestResult, error){
+    func() (TestResult, error) { return PairedTTest(sleepGroup1, sleepGroup2, 0, TwoSided, 0.95) },
+    func() (TestResult, error) { return PooledTTest(sleepGroup1, sleepGroup2, 0, TwoSided, 0.95) },
+    func() (TestResult, error) { return MannWhitneyUTest(sleepGroup1, sleepGroup2, TwoSided) },
+  } {
+    result, err := run()
+    if err != nil {
+      t.Fatal(err)
+    }
+    results = append(results, result)
+  }
+  corrected, reject, err := CorrectResults(results, Holm, 0.05)
+  if err != nil {
+    t.Fatal(err)
+  }
+  expected := []float64{ 0.008498670592158253, 0.13865515086725333, 0.13865515086725333 }
+  for i := range corrected {
+    if !floatsPicoEqual(corrected[i].PValue, expected[i]) {
+      t.Fatalf("\nPValue %d:\n  Expected: %f\n  Got: %f\n", i, expected[i], corrected[i].PValue)
+    }
+    if corrected[i].Statistic != results[i].Statistic {
+      t.Fatalf("\nStatistic %d:\n  Expected: %f\n  Got: %f\n", i, results[i].Statistic, corrected[i].Statistic)
+    }
+  }
+  if !reject[0] || reject[1] || reject[2] {
+    t.Fatalf("\nReject:\n  Expected: %v\n  Got: %v\n", []bool{ true, false, false }, reject)
+  }
+  if !floatsNanoEqual(results[0].PValue, 0.0028328901973860843) {
+    t.Fatal("\nExpected the original results to be unchanged.")
+  }
+}
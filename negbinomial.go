@@ -2,6 +2,7 @@ package prob
 
 import (
   "math"
+  "math/rand"
 )
 
 //The Negative Binomial Distribution is a discrete probability distribution
@@ -21,13 +22,26 @@ func NewNegBinomial(failures float64, prob float64) (NegBinomial, error) {
   return dist, nil
 }
 
-func (dist *NegBinomial) Validate() error {
-  dist.Failures = math.Floor(dist.Failures)
-  if dist.Failures < 0.0 {
-    return InvalidParamsError{ "Failures must be greater than zero." }
+// The negative binomial distribution with the given mean μ and dispersion α,
+// so that the variance is μ + αμ², using r = 1 / α and p = μ / (μ + r).
+// Failures need not be whole, as in the gamma-Poisson mixture.
+func NewNegBinomialMeanDispersion(mean, dispersion float64) (NegBinomial, error) {
+  if err := checkPositive("NegBinomial", "Mean", mean); err != nil {
+    return NegBinomial{}, err
   }
-  if dist.Prob < 0.0 || dist.Prob > 1.0 {
-    return InvalidParamsError{ "Prob must be between zero and one." }
+  if err := checkPositive("NegBinomial", "Dispersion", dispersion); err != nil {
+    return NegBinomial{}, err
+  }
+  failures := 1 / dispersion
+  return NewNegBinomial(failures, mean / (mean + failures))
+}
+
+func (dist NegBinomial) Validate() error {
+  if err := checkPositive("NegBinomial", "Failures", dist.Failures); err != nil {
+    return err
+  }
+  if err := checkProb("NegBinomial", "Prob", dist.Prob); err != nil {
+    return err
   }
   return nil
 }
@@ -53,7 +67,7 @@ func (dist NegBinomial) Kurtosis() float64 {
 }
 
 func (dist NegBinomial) StdDev() float64 {
-  result := math.Sqrt(dist.Failures * (1.0 - dist.Prob)) / dist.Prob
+  result := math.Sqrt(dist.Variance())
   return result
 }
 
@@ -64,27 +78,40 @@ func (dist NegBinomial) RelStdDev() float64 {
   return result
 }
 
+// Uses C(x + r - 1, x) pˣ (1 - p)ʳ = r / (x + r) C(x + r, r) pˣ (1 - p)ʳ and
+// Loader's saddle point expansion for the binomial probability.
 func (dist NegBinomial) Pdf(x float64) float64 {
   if x < 0.0 {
     return 0.0
   }
   x = math.Floor(x)
-  cnk := BinomialCoefficient(x + dist.Failures - 1.0, x)
-  pows := math.Pow(dist.Prob, x) * math.Pow(1.0 - dist.Prob, dist.Failures)
-  result := cnk * pows
+  binomial := binomialRaw(dist.Failures, x + dist.Failures, 1.0 - dist.Prob, dist.Prob)
+  result := dist.Failures / (x + dist.Failures) * binomial
   return result
 }
 
+// Uses P(X <= k) = I₁₋ₚ(r, k + 1).
 func (dist NegBinomial) Cdf(x float64) float64 {
-  result := 1.0 - RegBetaInc(x + 1.0, dist.Failures, dist.Prob)
+  if x < 0.0 {
+    return 0.0
+  }
+  result := RegBetaInc(dist.Failures, math.Floor(x) + 1.0, 1.0 - dist.Prob)
   return result
 }
 
+func (dist NegBinomial) Quantile(p float64) float64 {
+  return discreteQuantile(dist, p, 0, math.Inf(1))
+}
+
 // Ref: https://github.com/ampl/gsl/blob/48fbd40c7c9c24913a68251d23bdbd0637bbda20/randist/nbinomial.c
-func (dist NegBinomial) Random() float64 {
+func (dist NegBinomial) RandomWith(r *rand.Rand) float64 {
   rate := (1.0 - dist.Prob) / dist.Prob
-  g := Gamma{ Shape: dist.Failures, Rate: rate }.Random()
-  p := Poisson{ Mu: g }.Random()
+  g := Gamma{ Shape: dist.Failures, Rate: rate }.RandomWith(r)
+  p := Poisson{ Mu: g }.RandomWith(r)
   value := math.Floor(p + 0.5)
   return value
 }
+
+func (dist NegBinomial) Random() float64 {
+  return dist.RandomWith(nil)
+}
@@ -1,8 +1,13 @@
 package prob
 
-import "testing"
+import (
+  "math"
+  "testing"
+)
 
 // Test at http://keisan.casio.com/exec/system/1180573210
+// Large failures are tested against exact rational arithmetic and
+// I₁/₂(r, r) = 1/2.
 func Test_NegBinomial(t *testing.T) {
   examples := []distributionTest{
     distributionTest{
@@ -26,6 +31,41 @@ func Test_NegBinomial(t *testing.T) {
         inOut{ in: 20.0, out: 0.9786130273714661598206 },
       },
     },
+    distributionTest{
+      dist:       &NegBinomial{1e6, 0.5},
+      mean:       1e6,
+      variance:   2e6,
+      stdDev:     math.Sqrt(2e6),
+      relStdDev:  math.Sqrt(2e6) / 1e6,
+      skewness:   1.5 / math.Sqrt(5e5),
+      kurtosis:   6.5e-6,
+      pdf: []inOut{
+        inOut{ in: 1e6,      out: 0.0002820947565120314 },
+      },
+      cdf: []inOut{
+        inOut{ in: 999999.0, out: 0.5 },
+      },
+    },
+    // Failures need not be whole.
+    distributionTest{
+      dist:       NegBinomial{2.5, 0.4},
+      mean:       1.6666666666666667,
+      variance:   2.7777777777777777,
+      stdDev:     1.6666666666666667,
+      relStdDev:  1.0,
+      skewness:   1.4,
+      kurtosis:   2.76,
+      pdf: []inOut{
+        inOut{ in: 0.0,  out: 0.27885480092693404 },
+        inOut{ in: 3.0,  out: 0.11711901638931226 },
+        inOut{ in: 7.0,  out: 0.008134752252640508 },
+      },
+      cdf: []inOut{
+        inOut{ in: 0.0,  out: 0.27885480092693404 },
+        inOut{ in: 3.0,  out: 0.870026978892034 },
+        inOut{ in: 7.0,  out: 0.992821248189811 },
+      },
+    },
   }
 
 
@@ -43,3 +83,29 @@ func Benchmark_NegBinomial(b *testing.B) {
   dist := &NegBinomial{10.0, 0.5}
   runBenchmark(b, dist)
 }
+
+func Test_NegBinomial_Constructors(t *testing.T) {
+  dist, err := NewNegBinomialMeanDispersion(6, 0.25)
+  if err != nil || dist.Failures != 4 || !floatsPicoEqual(dist.Mean(), 6) || !floatsPicoEqual(dist.Variance(), 6 + (0.25 * 36)) {
+    t.Fatalf("\nMeanDispersion:\n  Expected: mean %f, variance %f\n  Got: %v, %v\n", 6.0, 15.0, dist, err)
+  }
+  // Both moments round-trip when 1 / α is not whole, or α is above one.
+  for _, dispersion := range []float64{ 0.3, 2 } {
+    dist, err = NewNegBinomialMeanDispersion(6, dispersion)
+    if err != nil || !floatsPicoEqual(dist.Failures, 1 / dispersion) || !floatsPicoEqual(dist.Mean(), 6) || !floatsPicoEqual(dist.Variance(), 6 + (dispersion * 36)) {
+      t.Fatalf("\nMeanDispersion:\n  Expected: mean %f, variance %f\n  Got: %v, %v\n", 6.0, 6 + (dispersion * 36), dist, err)
+    }
+  }
+  // Without failures the Cdf would need I(0, k + 1), which is undefined.
+  for _, failures := range []float64{ 0, -1 } {
+    _, err := NewNegBinomial(failures, 0.5)
+    if invalid, ok := err.(InvalidParamsError); !ok || invalid.Param != "Failures" {
+      t.Fatalf("\nFailures %v:\n  Expected: an InvalidParamsError for Failures\n  Got: %v\n", failures, err)
+    }
+  }
+  for _, invalid := range [][2]float64{ { 0, 0.25 }, { 6, 0 }, { 6, -1 } } {
+    if _, err := NewNegBinomialMeanDispersion(invalid[0], invalid[1]); err == nil {
+      t.Fatalf("\nExpected an error for %v.", invalid)
+    }
+  }
+}
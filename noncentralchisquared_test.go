@@ -0,0 +1,103 @@
+package prob
+
+import (
+  "testing"
+)
+
+// Test against Poisson mixtures of central chi-squared densities, and
+// numerical integration of the density for the moments.
+func Test_NoncentralChiSquared(t *testing.T) {
+  examples := []distributionTest{
+    distributionTest{
+      dist:       NoncentralChiSquared{6.0, 4.0},
+      mean:       10.0,
+      variance:   28.0,
+      stdDev:     5.291502622129181,
+      relStdDev:  0.5291502622129182,
+      skewness:   0.9719086448808698,
+      kurtosis:   4.346938775510204,
+      pdf: []inOut{
+        inOut{ in: 3.0,   out: 0.04178955781707811 },
+        inOut{ in: 10.0,  out: 0.07321967141470176 },
+        inOut{ in: -0.5,  out: 0.0 },
+      },
+      cdf: []inOut{
+        inOut{ in: 3.0,   out: 0.04955929038864601 },
+        inOut{ in: 10.0,  out: 0.5653534736475515 },
+        inOut{ in: -0.5,  out: 0.0 },
+      },
+    },
+    distributionTest{
+      dist:       NoncentralChiSquared{1.0, 2.5},
+      mean:       3.5,
+      variance:   12.0,
+      stdDev:     3.4641016151377544,
+      relStdDev:  0.989743318610787,
+      skewness:   1.6358257627039394,
+      kurtosis:   6.666666666666666,
+      pdf: []inOut{
+        inOut{ in: 0.5,   out: 0.21311256855381472 },
+        inOut{ in: 3.0,   out: 0.11433679167513452 },
+      },
+      cdf: []inOut{
+        inOut{ in: 0.5,   out: 0.1799887893515455 },
+        inOut{ in: 3.0,   out: 0.5595162319049038 },
+      },
+    },
+  }
+  if err := testValues(examples); err != nil {
+    t.Fatal(err)
+  }
+
+  // No noncentrality is the central distribution.
+  central, noncentral := ChiSquared{5.0}, NoncentralChiSquared{5.0, 0.0}
+  for _, x := range []float64{ 0.5, 4.0, 12.0 } {
+    if !floatsPicoEqual(central.Pdf(x), noncentral.Pdf(x)) || !floatsPicoEqual(central.Cdf(x), noncentral.Cdf(x)) {
+      t.Fatalf("\nCentral at %f:\n  Expected: %f, %f\n  Got: %f, %f\n", x, central.Pdf(x), central.Cdf(x), noncentral.Pdf(x), noncentral.Cdf(x))
+    }
+  }
+  if !floatsPicoEqual(central.Kurtosis(), noncentral.Kurtosis()) {
+    t.Fatalf("\nCentral Kurtosis:\n  Expected: %f\n  Got: %f\n", central.Kurtosis(), noncentral.Kurtosis())
+  }
+
+  // Large noncentrality against the Edgeworth expansion at the mean, which
+  // is off by O(λ^-3/2) there.
+  large := []struct {
+    lambda  float64
+    pdf     float64
+    cdf     float64
+  }{
+    { 1e8, 1.994711379566661e-05, 0.5000199471137707 },
+    { 1e9, 6.30783129795409e-06, 0.5000063078312972 },
+  }
+  for _, example := range large {
+    dist := NoncentralChiSquared{3.0, example.lambda}
+    x := dist.Mean()
+    if !floatsEqual(dist.Pdf(x) / example.pdf, 1, 1e-9) || !floatsEqual(dist.Cdf(x), example.cdf, 1e-11) {
+      t.Fatalf("\nLambda %v:\n  Expected: %v, %v\n  Got: %v, %v\n", example.lambda, example.pdf, example.cdf, dist.Pdf(x), dist.Cdf(x))
+    }
+  }
+  // The series and the approximation meet where one takes over.
+  series := NoncentralChiSquared{3.0, noncentral_large}
+  for _, x := range []float64{ noncentral_large - 2000, noncentral_large + 3, noncentral_large + 2000 } {
+    z, slope := series.sankaran(x)
+    if !floatsEqual(series.Cdf(x), Normal{0, 1}.Cdf(z), 1e-9) || !floatsEqual(series.Pdf(x), Normal{0, 1}.Pdf(z) * slope, 1e-12) {
+      t.Fatalf("\nSeries at %f:\n  Expected: %v, %v\n  Got: %v, %v\n", x, Normal{0, 1}.Pdf(z) * slope, Normal{0, 1}.Cdf(z), series.Pdf(x), series.Cdf(x))
+    }
+  }
+
+  if _, err := NewNoncentralChiSquared(2.0, -1.0); err == nil {
+    t.Fatal("\nExpected an error for a negative Lambda.")
+  }
+
+  for _, sample := range []Distribution{ NoncentralChiSquared{2.0, 1.0}, NoncentralChiSquared{0.5, 1.5} } {
+    if err := testSamples(sample); err != nil {
+      t.Fatal(err)
+    }
+  }
+}
+
+func Benchmark_NoncentralChiSquared(b *testing.B) {
+  dist := NoncentralChiSquared{6.0, 4.0}
+  runBenchmark(b, dist)
+}
@@ -0,0 +1,150 @@
+package prob
+
+import (
+  "math"
+  "math/rand"
+)
+
+//The Noncentral F Distribution is a continuous probability distribution
+// with parameters d1 > 0, d2 > 0 and λ >= 0.
+//
+// See: https://en.wikipedia.org/wiki/Noncentral_F-distribution
+type NoncentralF struct {
+  Degrees1  float64  `json:"degrees1"`
+  Degrees2  float64  `json:"degrees2"`
+  Lambda    float64  `json:"lambda"`
+}
+
+func NewNoncentralF(degrees1, degrees2, lambda float64) (NoncentralF, error) {
+  dist := NoncentralF{ degrees1, degrees2, lambda }
+  if err := dist.Validate(); err != nil {
+    return dist, err
+  }
+  return dist, nil
+}
+
+func (dist NoncentralF) Validate() error {
+  if err := checkPositive("NoncentralF", "Degrees1", dist.Degrees1); err != nil {
+    return err
+  }
+  if err := checkPositive("NoncentralF", "Degrees2", dist.Degrees2); err != nil {
+    return err
+  }
+  if err := checkNonNegative("NoncentralF", "Lambda", dist.Lambda); err != nil {
+    return err
+  }
+  return nil
+}
+
+// The raw moments E[Xⁿ] for n up to four, as the product of the moments of
+// the noncentral chi-squared numerator and the inverse chi-squared
+// denominator. Only valid when d2 > 8.
+func (dist NoncentralF) rawMoments() (float64, float64, float64, float64) {
+  d1, d2 := dist.Degrees1, dist.Degrees2
+  c1, c2, c3, c4 := NoncentralChiSquared{ d1, dist.Lambda }.rawMoments()
+  ratio := d2 / d1
+  i1 := 1 / (d2 - 2)
+  i2 := i1 / (d2 - 4)
+  i3 := i2 / (d2 - 6)
+  i4 := i3 / (d2 - 8)
+  return ratio * c1 * i1, ratio * ratio * c2 * i2, ratio * ratio * ratio * c3 * i3, ratio * ratio * ratio * ratio * c4 * i4
+}
+
+func (dist NoncentralF) Mean() float64 {
+  d1, d2 := dist.Degrees1, dist.Degrees2
+  if d2 <= 2 {
+    return math.NaN()
+  }
+  result := d2 * (d1 + dist.Lambda) / (d1 * (d2 - 2))
+  return result
+}
+
+func (dist NoncentralF) Variance() float64 {
+  d1, d2, l := dist.Degrees1, dist.Degrees2, dist.Lambda
+  if d2 <= 2 {
+    return math.NaN()
+  }
+  if d2 <= 4 {
+    return math.Inf(1)
+  }
+  result := 2 * (d2 / d1) * (d2 / d1) * (((d1 + l) * (d1 + l)) + ((d1 + (2 * l)) * (d2 - 2))) / ((d2 - 2) * (d2 - 2) * (d2 - 4))
+  return result
+}
+
+func (dist NoncentralF) Skewness() float64 {
+  if dist.Degrees2 <= 6 {
+    return math.NaN()
+  }
+  r1, r2, r3, _ := dist.rawMoments()
+  _, result, _ := shapeFromRaw(r1, r2, r3, 0)
+  return result
+}
+
+func (dist NoncentralF) Kurtosis() float64 {
+  if dist.Degrees2 <= 8 {
+    return math.NaN()
+  }
+  _, _, result := shapeFromRaw(dist.rawMoments())
+  return result
+}
+
+func (dist NoncentralF) StdDev() float64 {
+  result := math.Sqrt(dist.Variance())
+  return result
+}
+
+func (dist NoncentralF) RelStdDev() float64 {
+  result := dist.StdDev() / dist.Mean()
+  return result
+}
+
+// Given J = j from Poisson(λ / 2), X is (d1 + 2j) / d1 times a central F
+// with d1 + 2j and d2 degrees of freedom.
+func (dist NoncentralF) Pdf(x float64) float64 {
+  d1, d2 := dist.Degrees1, dist.Degrees2
+  if x < 0 {
+    return 0.0
+  }
+  if dist.Lambda == 0 {
+    return F{ d1, d2 }.Pdf(x)
+  }
+  result := poissonMixture(dist.Lambda / 2, func(j float64) float64 {
+    scale := d1 / (d1 + (2 * j))
+    return scale * F{ d1 + (2 * j), d2 }.Pdf(x * scale)
+  })
+  return result
+}
+
+func (dist NoncentralF) Cdf(x float64) float64 {
+  d1, d2 := dist.Degrees1, dist.Degrees2
+  if x <= 0 {
+    return 0.0
+  }
+  if dist.Lambda == 0 {
+    return F{ d1, d2 }.Cdf(x)
+  }
+  y := d1 * x / ((d1 * x) + d2)
+  result := poissonMixture(dist.Lambda / 2, func(j float64) float64 {
+    return RegBetaInc((d1 / 2) + j, d2 / 2, y)
+  })
+  return math.Min(result, 1.0)
+}
+
+func (dist NoncentralF) Quantile(p float64) float64 {
+  if p == 1 {
+    return math.Inf(1)
+  }
+  result := inverseCdf(dist.Cdf, p, 0, 2)
+  return result
+}
+
+func (dist NoncentralF) RandomWith(r *rand.Rand) float64 {
+  numerator := NoncentralChiSquared{ dist.Degrees1, dist.Lambda }.RandomWith(r)
+  denominator := ChiSquared{ dist.Degrees2 }.RandomWith(r)
+  result := (numerator / dist.Degrees1) / (denominator / dist.Degrees2)
+  return result
+}
+
+func (dist NoncentralF) Random() float64 {
+  return dist.RandomWith(nil)
+}
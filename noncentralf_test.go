@@ -0,0 +1,80 @@
+package prob
+
+import (
+  "math"
+  "testing"
+)
+
+// Test against numerical integration of Poisson mixtures of central F
+// densities.
+func Test_NoncentralF(t *testing.T) {
+  examples := []distributionTest{
+    distributionTest{
+      dist:       NoncentralF{5.0, 12.0, 3.0},
+      mean:       1.92,
+      variance:   2.5056,
+      stdDev:     1.5829087149927503,
+      relStdDev:  0.8244316223920575,
+      skewness:   2.941768959910778,
+      kurtosis:   25.369005152596113,
+      pdf: []inOut{
+        inOut{ in: 1.5,   out: 0.3553554709865912 },
+        inOut{ in: -0.5,  out: 0.0 },
+      },
+      cdf: []inOut{
+        inOut{ in: 1.5,   out: 0.49696597149241756 },
+        inOut{ in: -0.5,  out: 0.0 },
+      },
+    },
+    distributionTest{
+      dist:       NoncentralF{4.0, 20.0, 6.0},
+      mean:       2.7777777777777777,
+      variance:   3.742283950617284,
+      stdDev:     1.9344983718311277,
+      relStdDev:  0.696419413859206,
+      skewness:   1.8787452963652413,
+      kurtosis:   10.086573645294022,
+      pdf: []inOut{
+        inOut{ in: 3.0,   out: 0.19246291948530883 },
+      },
+      cdf: []inOut{
+        inOut{ in: 3.0,   out: 0.647268135458902 },
+      },
+    },
+  }
+  if err := testValues(examples); err != nil {
+    t.Fatal(err)
+  }
+
+  // No noncentrality is the central distribution.
+  central, noncentral := F{5.0, 12.0}, NoncentralF{5.0, 12.0, 0.0}
+  for _, x := range []float64{ 0.5, 1.5, 4.0 } {
+    if !floatsPicoEqual(central.Pdf(x), noncentral.Pdf(x)) || !floatsPicoEqual(central.Cdf(x), noncentral.Cdf(x)) {
+      t.Fatalf("\nCentral at %f:\n  Expected: %f, %f\n  Got: %f, %f\n", x, central.Pdf(x), central.Cdf(x), noncentral.Pdf(x), noncentral.Cdf(x))
+    }
+  }
+  if !floatsPicoEqual(central.Skewness(), noncentral.Skewness()) || !floatsPicoEqual(central.Kurtosis(), noncentral.Kurtosis()) {
+    t.Fatalf("\nCentral Skewness and Kurtosis:\n  Expected: %f, %f\n  Got: %f, %f\n", central.Skewness(), central.Kurtosis(), noncentral.Skewness(), noncentral.Kurtosis())
+  }
+
+  // The series would need more terms than it allows.
+  for _, lambda := range []float64{ 1e7, 1e9 } {
+    if dist := (NoncentralF{3.0, 20.0, lambda}); !math.IsNaN(dist.Cdf(1.0)) || !math.IsNaN(dist.Pdf(1.0)) {
+      t.Fatalf("\nLambda %v:\n  Expected: NaN\n  Got: %v, %v\n", lambda, dist.Pdf(1.0), dist.Cdf(1.0))
+    }
+  }
+
+  if _, err := NewNoncentralF(5.0, 12.0, -3.0); err == nil {
+    t.Fatal("\nExpected an error for a negative Lambda.")
+  }
+
+  sample := NoncentralF{10.0, 30.0, 2.0}
+  if err := testSamples(sample); err != nil {
+    t.Fatal(err)
+  }
+}
+
+func Benchmark_NoncentralF(b *testing.B) {
+  dist := NoncentralF{10.0, 30.0, 2.0}
+  runBenchmark(b, dist)
+}
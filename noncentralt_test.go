@@ -0,0 +1,104 @@
+package prob
+
+import (
+  "math"
+  "testing"
+)
+
+// Test against numerical integration over the chi-squared denominator.
+func Test_NoncentralT(t *testing.T) {
+  examples := []distributionTest{
+    distributionTest{
+      dist:       NoncentralT{10.0, 1.0},
+      mean:       1.0837223079391438,
+      variance:   1.3255459592750551,
+      stdDev:     1.151323568452872,
+      relStdDev:  1.0623787662378952,
+      skewness:   0.39992972990581366,
+      kurtosis:   4.249941318141374,
+      pdf: []inOut{
+        inOut{ in: 1.5,   out: 0.32474376767444885 },
+        inOut{ in: -0.5,  out: 0.12491165853269877 },
+      },
+      cdf: []inOut{
+        inOut{ in: 1.5,   out: 0.6695168482153554 },
+        inOut{ in: -0.5,  out: 0.06960168369324846 },
+      },
+    },
+    distributionTest{
+      dist:       NoncentralT{7.5, -0.8},
+      mean:       -0.8929012568926633,
+      variance:   1.439090981803139,
+      stdDev:     1.1996211826252232,
+      relStdDev:  -1.3435093448070161,
+      skewness:   -0.4924512354358576,
+      kurtosis:   5.131749262725691,
+      pdf: []inOut{
+        inOut{ in: 0.5,   out: 0.16310395761160545 },
+      },
+      cdf: []inOut{
+        inOut{ in: 0.5,   out: 0.8985825531903501 },
+        inOut{ in: -0.5,  out: 0.6232075887360640 },
+      },
+    },
+  }
+  if err := testValues(examples); err != nil {
+    t.Fatal(err)
+  }
+
+  // The density at zero has a closed form.
+  if out := (NoncentralT{10.0, 1.2}).Pdf(0); !floatsPicoEqual(out, 0.18939938370840456) {
+    t.Fatalf("\nPdf of 0:\n  Expected: %f\n  Got: %f\n", 0.18939938370840456, out)
+  }
+
+  // No noncentrality is the central distribution.
+  central, noncentral := StudentsT{10.0}, NoncentralT{10.0, 0.0}
+  for _, x := range []float64{ -2.0, 0.5, 1.5 } {
+    if !floatsPicoEqual(central.Pdf(x), noncentral.Pdf(x)) || !floatsPicoEqual(central.Cdf(x), noncentral.Cdf(x)) {
+      t.Fatalf("\nCentral at %f:\n  Expected: %f, %f\n  Got: %f, %f\n", x, central.Pdf(x), central.Cdf(x), noncentral.Pdf(x), noncentral.Cdf(x))
+    }
+  }
+  if !floatsPicoEqual(central.Kurtosis(), noncentral.Kurtosis()) {
+    t.Fatalf("\nCentral Kurtosis:\n  Expected: %f\n  Got: %f\n", central.Kurtosis(), noncentral.Kurtosis())
+  }
+
+  // Large δ still sums until its weights underflow, and then uses R's
+  // normal approximation, here Φ(-1/9) and 1 - Φ(1/9).
+  if out := (NoncentralT{10.0, 37.0}).Cdf(37.0); !(out > 0.4 && out < 0.5) {
+    t.Fatalf("\nDelta 37:\n  Expected: about %f\n  Got: %f\n", 0.44, out)
+  }
+  large := []struct {
+    delta  float64
+    x      float64
+    out    float64
+  }{
+    { 40.0, 40.0, 0.4557641189546886 },
+    { -40.0, -40.0, 0.5442358810453114 },
+  }
+  for _, example := range large {
+    dist := NoncentralT{10.0, example.delta}
+    if out := dist.Cdf(example.x); !floatsPicoEqual(out, example.out) {
+      t.Fatalf("\nDelta %f:\n  Expected: %v\n  Got: %v\n", example.delta, example.out, out)
+    }
+    if pdf := dist.Pdf(example.x); math.IsNaN(pdf) || pdf <= 0 {
+      t.Fatalf("\nDelta %f:\n  Expected: a positive density\n  Got: %v\n", example.delta, pdf)
+    }
+    if q := dist.Quantile(example.out); !floatsNanoEqual(q, example.x) {
+      t.Fatalf("\nDelta %f Quantile:\n  Expected: %v\n  Got: %v\n", example.delta, example.x, q)
+    }
+  }
+
+  if out := (NoncentralT{10.0, 1.0}).Quantile(0.6695168482153554); !floatsNanoEqual(out, 1.5) {
+    t.Fatalf("\nQuantile:\n  Expected: %f\n  Got: %f\n", 1.5, out)
+  }
+
+  sample := NoncentralT{15.0, 1.0}
+  if err := testSamples(sample); err != nil {
+    t.Fatal(err)
+  }
+}
+
+func Benchmark_NoncentralT(b *testing.B) {
+  dist := NoncentralT{15.0, 1.0}
+  runBenchmark(b, dist)
+}
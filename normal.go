@@ -26,8 +26,11 @@ func NewNormal(mu float64, sigma float64) (Normal, error) {
 }
 
 func (dist Normal) Validate() error {
-  if dist.Sigma < 0 {
-    return InvalidParamsError{ "Sigma must be greater than zero." }
+  if err := checkFinite("Normal", "Mu", dist.Mu); err != nil {
+    return err
+  }
+  if err := checkNonNegative("Normal", "Sigma", dist.Sigma); err != nil {
+    return err
   }
   return nil
 }
@@ -73,7 +76,40 @@ func (dist Normal) Cdf(x float64) float64 {
   return result
 }
 
-func (dist Normal) Random() float64 {
+func (dist Normal) PdfSlice(xs, out []float64) {
+  variance := dist.Variance()
+  denom := math.Sqrt(2 * variance * math.Pi)
+  for i, x := range xs {
+    diff := x - dist.Mu
+    out[i] = math.Exp(-1 * diff * diff / (2 * variance)) / denom
+  }
+}
+
+func (dist Normal) LogPdfSlice(xs, out []float64) {
+  variance := dist.Variance()
+  logDenom := math.Log(2 * variance * math.Pi) / 2
+  for i, x := range xs {
+    diff := x - dist.Mu
+    out[i] = (-1 * diff * diff / (2 * variance)) - logDenom
+  }
+}
+
+func (dist Normal) CdfSlice(xs, out []float64) {
+  scale := dist.Sigma * math.Sqrt(2)
+  for i, x := range xs {
+    out[i] = math.Abs(1 + math.Erf((x - dist.Mu) / scale)) / 2
+  }
+}
+
+func (dist Normal) Quantile(p float64) float64 {
+  if p < 0 || p > 1 {
+    return math.NaN()
+  }
+  result := dist.Mu + (dist.Sigma * math.Sqrt2 * math.Erfinv((2 * p) - 1))
+  return result
+}
+
+func (dist Normal) RandomWith(r *rand.Rand) float64 {
   // var value float64
   // if (skip) {
   //   value = dist.Mu + (next * dist.Sigma)
@@ -86,6 +122,10 @@ func (dist Normal) Random() float64 {
   //   value = dist.Mu + (z1 * dist.Sigma)
   //   skip = true
   // }
-  value := rand.NormFloat64() * dist.Sigma + dist.Mu
+  value := randNormFloat64(r) * dist.Sigma + dist.Mu
   return value
 }
+
+func (dist Normal) Random() float64 {
+  return dist.RandomWith(nil)
+}
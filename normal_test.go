@@ -49,6 +49,18 @@ func Test_Normal(t *testing.T) {
     t.Fatal(err)
   }
 
+  quantiles := []inOut{
+    inOut{ in: 0.025,  out: -6.839855938160214 },
+    inOut{ in: 0.5,    out: 1.0 },
+    inOut{ in: 0.975,  out: 8.839855938160214 },
+  }
+  for _, quantile := range quantiles {
+    out := Normal{1.0, 4.0}.Quantile(quantile.in)
+    if !floatsPicoEqual(out, quantile.out) {
+      t.Fatalf("\nQuantile of %f:\n  Expected: %f\n  Got: %f\n", quantile.in, quantile.out, out)
+    }
+  }
+
   sample := Normal{10.0, 4.0}
   if err := testSamples(sample); err != nil {
     t.Fatal(err)
@@ -59,3 +71,11 @@ func Benchmark_Normal(b *testing.B) {
   dist := Normal{10.0, 4.0}
   runBenchmark(b, dist)
 }
+
+func Benchmark_Normal_Pdf(b *testing.B) {
+  runPdfBenchmark(b, Normal{ 0.0, 1.0 }, -5, 5)
+}
+
+func Benchmark_Normal_PdfSlice(b *testing.B) {
+  runPdfSliceBenchmark(b, Normal{ 0.0, 1.0 }, -5, 5)
+}
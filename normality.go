@@ -0,0 +1,255 @@
+package prob
+
+import (
+  "math"
+  "sort"
+)
+
+// Which parameters of the hypothesized distribution were estimated from the
+// sample, selecting the null distribution of the Anderson–Darling statistic.
+type ADCase int
+
+const (
+  // Every parameter was fixed in advance.
+  ADSpecified ADCase = iota
+  // A Normal whose μ and σ are the sample mean and standard deviation.
+  ADNormalEstimated
+)
+
+// Upper-tail significance levels of the tabulated critical values.
+var adSignificance = []float64{ 0.15, 0.10, 0.05, 0.025, 0.01 }
+
+// Critical values of A² by case.
+// Ref: Stephens (1974), EDF statistics for goodness of fit and some comparisons.
+var adCritical = map[ADCase][]float64{
+  ADSpecified:        { 1.610, 1.933, 2.492, 3.070, 3.857 },
+  ADNormalEstimated:  { 0.576, 0.656, 0.787, 0.918, 1.092 },
+}
+
+// The Anderson–Darling statistic A² of samples against a cdf.
+func andersonDarling(sorted []float64, cdf func(float64) float64) float64 {
+  n := float64(len(sorted))
+  sum := 0.0
+  for i, x := range sorted {
+    lower := cdf(x)
+    upper := 1 - cdf(sorted[len(sorted)-1-i])
+    sum += float64((2 * i) + 1) * (math.Log(lower) + math.Log(upper))
+  }
+  return -n - (sum / n)
+}
+
+// The Anderson–Darling test that samples come from dist. When the case is
+// ADNormalEstimated, dist is ignored and a Normal is fitted to the samples;
+// the p-value then comes from D'Agostino & Stephens' approximation for the
+// modified statistic A²(1 + 0.75/n + 2.25/n²). Otherwise the p-value uses
+// Marsaglia & Marsaglia's finite-sample approximation.
+//
+// See: https://en.wikipedia.org/wiki/Anderson%E2%80%93Darling_test
+func AndersonDarlingTest(samples []float64, dist Distribution, c ADCase) (TestResult, error) {
+  if c != ADSpecified && c != ADNormalEstimated {
+    return TestResult{}, InvalidParamsError{ S: "Case must be ADSpecified or ADNormalEstimated." }
+  }
+  if len(samples) < 3 {
+    return TestResult{}, InvalidParamsError{ S: "Samples must contain at least three values." }
+  }
+  sorted := append([]float64{}, samples...)
+  sort.Float64s(sorted)
+  n := float64(len(sorted))
+  var statistic, p float64
+  switch c {
+  case ADNormalEstimated:
+    mean, variance := sampleMoments(sorted)
+    if !(variance > 0) {
+      return TestResult{}, InvalidParamsError{ S: "Samples must not all be equal." }
+    }
+    dist = Normal{ mean, math.Sqrt(variance) }
+    statistic = andersonDarling(sorted, dist.Cdf)
+    p = adNormalPValue(statistic * (1 + (0.75 / n) + (2.25 / (n * n))))
+  default:
+    if dist == nil {
+      return TestResult{}, InvalidParamsError{ S: "Dist must not be nil for ADSpecified." }
+    }
+    if err := dist.Validate(); err != nil {
+      return TestResult{}, err
+    }
+    statistic = andersonDarling(sorted, dist.Cdf)
+    p = 1 - adCdf(len(sorted), statistic)
+  }
+  return TestResult{
+    Statistic:    statistic,
+    Degrees:      math.NaN(),
+    Degrees2:     math.NaN(),
+    PValue:       math.Max(0.0, math.Min(p, 1.0)),
+    Alternative:  Greater,
+    Estimate:     math.NaN(),
+    Lower:        math.NaN(),
+    Upper:        math.NaN(),
+  }, nil
+}
+
+// The critical value of A² for n samples at one of the tabulated significance
+// levels 0.15, 0.10, 0.05, 0.025 or 0.01, adjusted for n as in Stephens (1974).
+func AndersonDarlingCriticalValue(c ADCase, n int, significance float64) (float64, error) {
+  table, ok := adCritical[c]
+  if !ok {
+    return math.NaN(), InvalidParamsError{ S: "Case must be ADSpecified or ADNormalEstimated." }
+  }
+  if n < 3 {
+    return math.NaN(), InvalidParamsError{ S: "N must be at least three." }
+  }
+  for i, level := range adSignificance {
+    if level == significance {
+      if c == ADNormalEstimated {
+        m := float64(n)
+        return table[i] / (1 + (4 / m) - (25 / (m * m))), nil
+      }
+      return table[i], nil
+    }
+  }
+  return math.NaN(), InvalidParamsError{ S: "Significance must be 0.15, 0.10, 0.05, 0.025 or 0.01." }
+}
+
+// Ref: D'Agostino & Stephens (1986), Goodness-of-Fit Techniques, Table 4.9.
+func adNormalPValue(a float64) float64 {
+  switch {
+  case a >= 0.6:
+    return math.Exp(1.2937 - (5.709 * a) + (0.0186 * a * a))
+  case a >= 0.34:
+    return math.Exp(0.9177 - (4.279 * a) - (1.38 * a * a))
+  case a >= 0.2:
+    return 1 - math.Exp(-8.318 + (42.796 * a) - (59.938 * a * a))
+  }
+  return 1 - math.Exp(-13.436 + (101.14 * a) - (223.73 * a * a))
+}
+
+// The cdf of A² for n samples from a fully specified distribution.
+// Ref: Marsaglia & Marsaglia (2004), Evaluating the Anderson-Darling distribution.
+func adCdf(n int, z float64) float64 {
+  x := adInf(z)
+  return x + adErrFix(n, x)
+}
+
+// The asymptotic cdf of A².
+func adInf(z float64) float64 {
+  if z <= 0 {
+    return 0.0
+  }
+  if z < 2 {
+    return math.Exp(-1.2337141 / z) / math.Sqrt(z) * (2.00012 + (0.247105 - (0.0649821 - (0.0347962 - (0.011672 - 0.00168691 * z) * z) * z) * z) * z)
+  }
+  return math.Exp(-math.Exp(1.0776 - (2.30695 - (0.43424 - (0.082433 - (0.008056 - 0.0003146 * z) * z) * z) * z) * z))
+}
+
+// The correction to the asymptotic cdf value x for n samples.
+func adErrFix(n int, x float64) float64 {
+  m := float64(n)
+  if x > 0.8 {
+    return (-130.2137 + (745.2337 - (1705.091 - (1950.646 - (1116.360 - 255.7844 * x) * x) * x) * x) * x) / m
+  }
+  c := 0.01265 + (0.1757 / m)
+  if x < c {
+    t := x / c
+    t = math.Sqrt(t) * (1 - t) * ((49 * t) - 102)
+    return t * ((0.0037 / (m * m)) + (0.00078 / m) + 0.00006) / m
+  }
+  t := (x - c) / (0.8 - c)
+  t = -0.00022633 + (6.54034 - (14.6538 - (14.458 - (8.259 - 1.91864 * t) * t) * t) * t) * t
+  return t * ((0.04213 / m) + (0.01365 / (m * m))) / m
+}
+
+// Evaluates c[0] + c[1]x + c[2]x² + ...
+func polynomial(c []float64, x float64) float64 {
+  result := 0.0
+  for i := len(c) - 1; i >= 0; i-- {
+    result = (result * x) + c[i]
+  }
+  return result
+}
+
+// The Shapiro–Wilk test of normality for 3 to 5000 samples, using Royston's
+// approximations for the coefficients and the p-value of W.
+//
+// See: https://en.wikipedia.org/wiki/Shapiro%E2%80%93Wilk_test
+// Ref: Royston (1995), Algorithm AS R94.
+func ShapiroWilkTest(samples []float64) (TestResult, error) {
+  n := len(samples)
+  if n < 3 || n > 5000 {
+    return TestResult{}, InvalidParamsError{ S: "Samples must contain between 3 and 5000 values." }
+  }
+  sorted := append([]float64{}, samples...)
+  sort.Float64s(sorted)
+  if sorted[n-1] - sorted[0] == 0 {
+    return TestResult{}, InvalidParamsError{ S: "Samples must not all be equal." }
+  }
+  an := float64(n)
+  half := n / 2
+  a := make([]float64, half)
+  if n == 3 {
+    a[0] = math.Sqrt(0.5)
+  } else {
+    m := make([]float64, half)
+    summ2 := 0.0
+    for i := range m {
+      m[i] = Normal{ 0, 1 }.Quantile((float64(i + 1) - 0.375) / (an + 0.25))
+      summ2 += m[i] * m[i]
+    }
+    summ2 *= 2
+    ssumm2 := math.Sqrt(summ2)
+    rsn := 1 / math.Sqrt(an)
+    a1 := polynomial([]float64{ 0, 0.221157, -0.147981, -2.07119, 4.434685, -2.706056 }, rsn) - (m[0] / ssumm2)
+    start := 1
+    fac := math.Sqrt((summ2 - (2 * m[0] * m[0])) / (1 - (2 * a1 * a1)))
+    if n > 5 {
+      start = 2
+      a2 := polynomial([]float64{ 0, 0.042981, -0.293762, -1.752461, 5.682633, -3.582633 }, rsn) - (m[1] / ssumm2)
+      fac = math.Sqrt((summ2 - (2 * m[0] * m[0]) - (2 * m[1] * m[1])) / (1 - (2 * a1 * a1) - (2 * a2 * a2)))
+      a[1] = a2
+    }
+    a[0] = a1
+    for i := start; i < half; i++ {
+      a[i] = -m[i] / fac
+    }
+  }
+  numer := 0.0
+  for i := range a {
+    numer += a[i] * (sorted[n-1-i] - sorted[i])
+  }
+  _, variance := sampleMoments(sorted)
+  w := numer * numer / (variance * (an - 1))
+  w = math.Min(w, 1.0)
+  return TestResult{
+    Statistic:    w,
+    Degrees:      math.NaN(),
+    Degrees2:     math.NaN(),
+    PValue:       shapiroWilkPValue(n, w),
+    Alternative:  Less,
+    Estimate:     math.NaN(),
+    Lower:        math.NaN(),
+    Upper:        math.NaN(),
+  }, nil
+}
+
+// The upper-tail p-value of -log(1 - W), which Royston normalizes for n > 3.
+func shapiroWilkPValue(n int, w float64) float64 {
+  an := float64(n)
+  if n == 3 {
+    p := 6 / math.Pi * (math.Asin(math.Sqrt(w)) - (math.Pi / 3))
+    return math.Max(p, 0.0)
+  }
+  y := math.Log(1 - w)
+  var mu, sigma float64
+  if n <= 11 {
+    gamma := polynomial([]float64{ -2.273, 0.459 }, an)
+    if y >= gamma {
+      return 0.0
+    }
+    y = -math.Log(gamma - y)
+    mu = polynomial([]float64{ 0.544, -0.39978, 0.025054, -6.714e-4 }, an)
+    sigma = math.Exp(polynomial([]float64{ 1.3822, -0.77857, 0.062767, -0.0020322 }, an))
+  } else {
+    logn := math.Log(an)
+    mu = polynomial([]float64{ -1.5861, -0.31082, -0.083751, 0.0038915 }, logn)
+    sigma = math.Exp(polynomial([]float64{ -0.4803, -0.082676, 0.0030302 }, logn))
+  }
+  return 1 - Normal{ mu, sigma }.Cdf(y)
+}
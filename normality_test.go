@@ -0,0 +1,111 @@
+package prob
+
+import (
+  "errors"
+  "math/rand"
+  "testing"
+)
+
+// Weights of 11 men, from Shapiro & Wilk (1965).
+var menWeights = []float64{ 148, 154, 158, 160, 161, 162, 166, 170, 182, 195, 236 }
+
+// Test against R's shapiro.test and nortest::ad.test, with A² checked by
+// direct computation.
+func Test_Normality(t *testing.T) {
+  results := []rankTest{}
+  add := func(statistic, pValue float64) func(TestResult, error) {
+    return func(result TestResult, err error) {
+      if err != nil {
+        t.Fatal(err)
+      }
+      results = append(results, rankTest{ result, statistic, pValue })
+    }
+  }
+  add(0.7888146948353882, 0.00670381405650311)(ShapiroWilkTest(menWeights))
+  add(0.9258060289366404, 0.4079287964299877)(ShapiroWilkTest(sleepGroup1))
+  add(0.9192977012814972, 0.3511346854076519)(ShapiroWilkTest(sleepGroup2))
+  add(0.3469067308107512, 0.4019278195142029)(AndersonDarlingTest(sleepGroup1, nil, ADNormalEstimated))
+  add(0.9028477175857557, 0.4122119677489464)(AndersonDarlingTest(sleepGroup1, Normal{ 0, 2 }, ADSpecified))
+  for _, example := range results {
+    if !floatsPicoEqual(example.result.Statistic, example.statistic) {
+      t.Fatalf("\nStatistic:\n  Expected: %f\n  Got: %f\n", example.statistic, example.result.Statistic)
+    }
+    if !floatsNanoEqual(example.result.PValue, example.pValue) {
+      t.Fatalf("\nPValue:\n  Expected: %f\n  Got: %f\n", example.pValue, example.result.PValue)
+    }
+  }
+
+  // Three samples have an exact distribution for W.
+  result, err := ShapiroWilkTest([]float64{ 1, 2, 4 })
+  if err != nil || !floatsPicoEqual(result.Statistic, 0.9642857142857146) || !floatsNanoEqual(result.PValue, 0.6368868450289714) {
+    t.Fatalf("\nShapiroWilk(n = 3):\n  Expected: %f, %f\n  Got: %f, %f\n", 0.9642857142857146, 0.6368868450289714, result.Statistic, result.PValue)
+  }
+
+  if _, err := ShapiroWilkTest([]float64{ 1, 2 }); err == nil {
+    t.Fatal("\nExpected an error for two samples.")
+  }
+  if _, err := ShapiroWilkTest([]float64{ 3, 3, 3, 3 }); err == nil {
+    t.Fatal("\nExpected an error for constant samples.")
+  }
+  if _, err := AndersonDarlingTest(sleepGroup1, Normal{ 0, -1 }, ADSpecified); err == nil {
+    t.Fatal("\nExpected an error for an invalid distribution.")
+  }
+  if _, err := AndersonDarlingTest(sleepGroup1, nil, ADSpecified); !errors.Is(err, ErrInvalidParams) {
+    t.Fatalf("\nNil distribution:\n  Expected: %v\n  Got: %v\n", ErrInvalidParams, err)
+  }
+}
+
+// Asymptotic cdf at the tabulated critical values.
+func Test_Normality_AndersonDarlingDistribution(t *testing.T) {
+  for i, critical := range adCritical[ADSpecified] {
+    expected := 1 - adSignificance[i]
+    if got := adInf(critical); !floatsCentiEqual(got, expected) {
+      t.Fatalf("\nadInf(%f):\n  Expected: %f\n  Got: %f\n", critical, expected, got)
+    }
+  }
+  value, err := AndersonDarlingCriticalValue(ADNormalEstimated, 20, 0.05)
+  if err != nil || !floatsPicoEqual(value, 0.787 / 1.1375) {
+    t.Fatalf("\nCriticalValue:\n  Expected: %f\n  Got: %f\n", 0.787 / 1.1375, value)
+  }
+  if _, err := AndersonDarlingCriticalValue(ADSpecified, 20, 0.2); err == nil {
+    t.Fatal("\nExpected an error for an untabulated significance.")
+  }
+}
+
+// Normal samples should be rejected about as often as the significance level,
+// and skewed samples nearly always.
+func Test_Normality_Calibration(t *testing.T) {
+  rng := rand.New(rand.NewSource(1))
+  trials := 2000
+  rejected := map[string]int{}
+  samples := make([]float64, 50)
+  for i := 0; i < trials; i++ {
+    if err := SampleInto(Normal{ 5, 2 }, samples, rng); err != nil {
+      t.Fatal(err)
+    }
+    if result, _ := ShapiroWilkTest(samples); result.PValue < 0.05 {
+      rejected["ShapiroWilk"]++
+    }
+    if result, _ := AndersonDarlingTest(samples, nil, ADNormalEstimated); result.PValue < 0.05 {
+      rejected["AndersonDarling"]++
+    }
+    if result, _ := AndersonDarlingTest(samples, Normal{ 5, 2 }, ADSpecified); result.PValue < 0.05 {
+      rejected["Specified"]++
+    }
+  }
+  for name, count := range rejected {
+    if rate := float64(count) / float64(trials); rate < 0.03 || rate > 0.07 {
+      t.Fatalf("\n%s rejection rate:\n  Expected: %f\n  Got: %f\n", name, 0.05, rate)
+    }
+  }
+  samples = make([]float64, 100)
+  if err := SampleInto(Exponential{ 1 }, samples, rng); err != nil {
+    t.Fatal(err)
+  }
+  if result, _ := ShapiroWilkTest(samples); result.PValue > 0.001 {
+    t.Fatalf("\nShapiroWilk(exponential):\n  Expected: < %f\n  Got: %f\n", 0.001, result.PValue)
+  }
+  if result, _ := AndersonDarlingTest(samples, nil, ADNormalEstimated); result.PValue > 0.001 {
+    t.Fatalf("\nAndersonDarling(exponential):\n  Expected: < %f\n  Got: %f\n", 0.001, result.PValue)
+  }
+}
@@ -23,11 +23,11 @@ func NewPareto(scale float64, shape float64) (Pareto, error) {
 }
 
 func (dist Pareto) Validate() error {
-  if dist.Scale <= 0 {
-    return InvalidParamsError{ "Scale must be greater than zero." }
+  if err := checkPositive("Pareto", "Scale", dist.Scale); err != nil {
+    return err
   }
-  if dist.Shape <= 0 {
-    return InvalidParamsError{ "Shape must be greater than zero." }
+  if err := checkPositive("Pareto", "Shape", dist.Shape); err != nil {
+    return err
   }
   return nil
 }
@@ -102,7 +102,19 @@ func (dist Pareto) Cdf(x float64) float64 {
   return result
 }
 
-func (dist Pareto) Random() float64 {
-  value := dist.Scale / math.Pow(rand.Float64(), 1 / dist.Shape)
+func (dist Pareto) Quantile(p float64) float64 {
+  if p < 0 || p > 1 || math.IsNaN(p) {
+    return math.NaN()
+  }
+  result := dist.Scale * math.Exp(-math.Log1p(-p) / dist.Shape)
+  return result
+}
+
+func (dist Pareto) RandomWith(r *rand.Rand) float64 {
+  value := dist.Scale / math.Pow(randFloat64(r), 1 / dist.Shape)
   return value
 }
+
+func (dist Pareto) Random() float64 {
+  return dist.RandomWith(nil)
+}
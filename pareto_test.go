@@ -64,9 +64,9 @@ func Test_Pareto(t *testing.T) {
 
 // This computes and compares parameters to MLE results.
 func estimatePareto(dist Pareto) error {
-  samples := Sample(dist, numSamples)
-  if len(samples) != numSamples {
-    return fmt.Errorf("\nCould not generate samples.")
+  samples, err := Sample(dist, numSamples)
+  if err != nil || len(samples) != numSamples {
+    return fmt.Errorf("\nCould not generate samples: %v", err)
   }
   n := float64(numSamples)
   min := math.Inf(1)
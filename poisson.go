@@ -22,8 +22,8 @@ func NewPoisson(mu float64) (Poisson, error) {
 }
 
 func (dist Poisson) Validate() error {
-  if dist.Mu <= 0 {
-    return InvalidParamsError{ "Mu must be greater than zero." }
+  if err := checkPositive("Poisson", "Mu", dist.Mu); err != nil {
+    return err
   }
   return nil
 }
@@ -66,19 +66,23 @@ func (dist Poisson) Cdf(x float64) float64 {
   if (x < 0.0) {
     return 0.0
   }
-  result := 1 - GammaIncLower(math.Floor(x + 1), dist.Mu)
+  result := GammaIncUpper(math.Floor(x + 1), dist.Mu)
   return result
 }
 
-func (dist Poisson) Random() float64 {
+func (dist Poisson) Quantile(p float64) float64 {
+  return discreteQuantile(dist, p, 0, math.Inf(1))
+}
+
+func (dist Poisson) RandomWith(r *rand.Rand) float64 {
   mu := dist.Mu
   k := 0.0
   for mu > 10.0 {
     m := math.Floor((mu * (7.0/8.0)) + 0.5)
-    x := Gamma{ Shape: m, Rate: 1.0 }.Random()
+    x := Gamma{ Shape: m, Rate: 1.0 }.RandomWith(r)
     if x >= mu {
-      rand := Binomial{ Prob: mu / x, Trials: m - 1 }.Random()
-      return k + rand
+      binomial := Binomial{ Prob: mu / x, Trials: m - 1 }.RandomWith(r)
+      return k + binomial
     }
     k += m
     mu -= x
@@ -86,9 +90,13 @@ func (dist Poisson) Random() float64 {
   prod := 1.0
   emu := math.Exp(-mu)
   for ok := true; ok; {
-    prod *= rand.Float64()
+    prod *= randFloat64(r)
     k++
     ok = prod > emu
   }
   return k - 1.0
 }
+
+func (dist Poisson) Random() float64 {
+  return dist.RandomWith(nil)
+}
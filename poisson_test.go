@@ -55,6 +55,11 @@ func Test_Poisson(t *testing.T) {
     t.Fatal(err)
   }
 
+  // The lower tail keeps its relative precision.
+  if out := (Poisson{50.0}).Cdf(10); math.Abs((out / 6.450152918497726e-12) - 1) > 1e-12 {
+    t.Fatalf("\nCdf of %f:\n  Expected: %g\n  Got: %g\n", 10.0, 6.450152918497726e-12, out)
+  }
+
   sample := Poisson{11.0}
   if err := testSamples(sample); err != nil {
     t.Fatal(err)
@@ -0,0 +1,161 @@
+package prob
+
+import (
+  "math"
+)
+
+// The inputs and result of a power calculation. Effect is a standardized
+// effect size: Cohen's d for z- and t-tests, Cohen's h for proportions and
+// Cohen's w for chi-squared tests. N counts observations per group for
+// two-sample designs. Exactly one of Effect, N, Alpha and Power must be
+// zero; that one is solved for and filled in. Effect must be negative for
+// Less and positive for Greater.
+type PowerParams struct {
+  Effect       float64      `json:"effect"`
+  N            float64      `json:"n"`
+  Alpha        float64      `json:"alpha"`
+  Power        float64      `json:"power"`
+  Alternative  Alternative  `json:"alternative"`
+}
+
+// The layout of the samples in a t-test.
+type TTestDesign int
+
+const (
+  OneSampleDesign TTestDesign = iota
+  PairedDesign
+  TwoSampleDesign
+)
+
+// Fills in the one zero field of params, given the power as a function of
+// effect, n and alpha. N must be greater than minN.
+func solvePower(params PowerParams, minN float64, power func(effect, n, alpha float64) float64) (PowerParams, error) {
+  if err := validateAlternative(params.Alternative); err != nil {
+    return params, err
+  }
+  unknown := 0
+  for _, value := range []float64{ params.Effect, params.N, params.Alpha, params.Power } {
+    if value == 0 {
+      unknown++
+    }
+  }
+  if unknown != 1 {
+    return params, InvalidParamsError{ S: "Exactly one of Effect, N, Alpha and Power must be zero." }
+  }
+  if params.Alpha != 0 && !(params.Alpha > 0 && params.Alpha < 1) {
+    return params, InvalidParamsError{ S: "Alpha must be between zero and one." }
+  }
+  if params.Power != 0 && !(params.Power > 0 && params.Power < 1) {
+    return params, InvalidParamsError{ S: "Power must be between zero and one." }
+  }
+  if params.N != 0 && !(params.N > minN) {
+    return params, InvalidParamsError{ S: "N is too small for the test." }
+  }
+  if (params.Alternative == Less && params.Effect > 0) || (params.Alternative == Greater && params.Effect < 0) {
+    return params, InvalidParamsError{ S: "Effect must be in the direction of the Alternative." }
+  }
+  if (params.Effect == 0 || params.N == 0) && !(params.Power > params.Alpha) {
+    return params, InvalidParamsError{ S: "Power must be greater than Alpha." }
+  }
+  switch {
+  case params.Power == 0:
+    params.Power = power(params.Effect, params.N, params.Alpha)
+  case params.N == 0:
+    f := func(n float64) float64 { return power(params.Effect, n, params.Alpha) }
+    params.N = inverseCdf(f, params.Power, minN, minN + 10)
+  case params.Alpha == 0:
+    f := func(alpha float64) float64 { return power(params.Effect, params.N, alpha) }
+    params.Alpha = inverseCdf(f, params.Power, 0, 1)
+  default:
+    sign := 1.0
+    if params.Alternative == Less {
+      sign = -1.0
+    }
+    f := func(effect float64) float64 { return power(sign * effect, params.N, params.Alpha) }
+    params.Effect = sign * inverseCdf(f, params.Power, 0, 1)
+  }
+  return params, nil
+}
+
+// The power of a test whose statistic is normal with unit variance and mean
+// shift under the alternative.
+func normalPower(shift, alpha float64, alt Alternative) float64 {
+  dist := Normal{ 0, 1 }
+  switch alt {
+  case Less:
+    return dist.Cdf(dist.Quantile(alpha) - shift)
+  case Greater:
+    return 1 - dist.Cdf(dist.Quantile(1 - alpha) - shift)
+  }
+  q := dist.Quantile(1 - (alpha / 2))
+  return 1 - dist.Cdf(q - shift) + dist.Cdf(-q - shift)
+}
+
+// Power and sample size for the one-sample z-test, where Effect is
+// (μ - μ₀) / σ.
+//
+// See: https://en.wikipedia.org/wiki/Power_of_a_test
+func ZTestPower(params PowerParams) (PowerParams, error) {
+  return solvePower(params, 0, func(effect, n, alpha float64) float64 {
+    return normalPower(effect * math.Sqrt(n), alpha, params.Alternative)
+  })
+}
+
+// Power and sample size for Student's t-test, where Effect is the difference
+// in means over the standard deviation, using the noncentral t distribution.
+// For paired designs the standard deviation is that of the differences.
+func TTestPower(params PowerParams, design TTestDesign) (PowerParams, error) {
+  if design < OneSampleDesign || design > TwoSampleDesign {
+    return params, InvalidParamsError{ S: "Design must be OneSampleDesign, PairedDesign or TwoSampleDesign." }
+  }
+  samples := 1.0
+  if design == TwoSampleDesign {
+    samples = 2.0
+  }
+  return solvePower(params, 1, func(effect, n, alpha float64) float64 {
+    degrees := (n - 1) * samples
+    delta := effect * math.Sqrt(n / samples)
+    central := NoncentralT{ degrees, 0 }
+    dist := NoncentralT{ degrees, delta }
+    switch params.Alternative {
+    case Less:
+      return dist.Cdf(central.Quantile(alpha))
+    case Greater:
+      return 1 - dist.Cdf(central.Quantile(1 - alpha))
+    }
+    q := central.Quantile(1 - (alpha / 2))
+    return 1 - dist.Cdf(q) + dist.Cdf(-q)
+  })
+}
+
+// Cohen's effect size h for the difference between two proportions.
+//
+// See: https://en.wikipedia.org/wiki/Cohen%27s_h
+func CohenH(p1, p2 float64) float64 {
+  result := (2 * math.Asin(math.Sqrt(p1))) - (2 * math.Asin(math.Sqrt(p2)))
+  return result
+}
+
+// Power and sample size for the two-proportion z-test with equal group
+// sizes, where Effect is Cohen's h.
+func TwoProportionPower(params PowerParams) (PowerParams, error) {
+  return solvePower(params, 0, func(effect, n, alpha float64) float64 {
+    return normalPower(effect * math.Sqrt(n / 2), alpha, params.Alternative)
+  })
+}
+
+// Power and sample size for chi-squared tests with the given degrees of
+// freedom, where Effect is Cohen's w and N is the total count. The test is
+// always upper-tailed, so Alternative must be Greater.
+func ChiSquaredPower(params PowerParams, degrees float64) (PowerParams, error) {
+  if params.Alternative != Greater {
+    return params, InvalidParamsError{ S: "Alternative must be Greater." }
+  }
+  if !(degrees > 0) {
+    return params, InvalidParamsError{ S: "Degrees must be greater than zero." }
+  }
+  return solvePower(params, 0, func(effect, n, alpha float64) float64 {
+    critical := ChiSquared{ degrees }.Quantile(1 - alpha)
+    return 1 - NoncentralChiSquared{ degrees, n * effect * effect }.Cdf(critical)
+  })
+}
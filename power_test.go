@@ -0,0 +1,69 @@
+package prob
+
+import (
+  "testing"
+)
+
+// Test against R's power.t.test(strict = TRUE) and the pwr package, to their
+// printed precision.
+func Test_Power(t *testing.T) {
+  examples := []struct{
+    name      string
+    solve     func() (PowerParams, error)
+    got       func(PowerParams) float64
+    expected  float64
+  }{
+    { "TTest Power",
+      func() (PowerParams, error) { return TTestPower(PowerParams{ Effect: 1, N: 20, Alpha: 0.05 }, TwoSampleDesign) },
+      func(p PowerParams) float64 { return p.Power }, 0.8689530 },
+    { "TTest N",
+      func() (PowerParams, error) { return TTestPower(PowerParams{ Effect: 1, Power: 0.9, Alpha: 0.05 }, TwoSampleDesign) },
+      func(p PowerParams) float64 { return p.N }, 22.02109 },
+    { "TTest N, Greater",
+      func() (PowerParams, error) { return TTestPower(PowerParams{ Effect: 1, Power: 0.9, Alpha: 0.05, Alternative: Greater }, TwoSampleDesign) },
+      func(p PowerParams) float64 { return p.N }, 17.84713 },
+    { "TTest Effect",
+      func() (PowerParams, error) { return TTestPower(PowerParams{ N: 20, Power: 0.9, Alpha: 0.05 }, TwoSampleDesign) },
+      func(p PowerParams) float64 { return p.Effect }, 1.051993 },
+    { "TTest Effect, Less",
+      func() (PowerParams, error) { return TTestPower(PowerParams{ N: 30, Power: 0.8482541909289822, Alpha: 0.05, Alternative: Less }, OneSampleDesign) },
+      func(p PowerParams) float64 { return p.Effect }, -0.5 },
+    { "TTest Alpha",
+      func() (PowerParams, error) { return TTestPower(PowerParams{ Effect: 1, N: 20, Power: 0.9 }, TwoSampleDesign) },
+      func(p PowerParams) float64 { return p.Alpha }, 0.07005322 },
+    { "ZTest N",
+      func() (PowerParams, error) { return ZTestPower(PowerParams{ Effect: 0.5, Power: 0.8, Alpha: 0.05 }) },
+      func(p PowerParams) float64 { return p.N }, 31.39544 },
+    { "TwoProportion Power",
+      func() (PowerParams, error) { return TwoProportionPower(PowerParams{ Effect: 0.3, N: 80, Alpha: 0.05 }) },
+      func(p PowerParams) float64 { return p.Power }, 0.4751009 },
+    { "ChiSquared Power",
+      func() (PowerParams, error) { return ChiSquaredPower(PowerParams{ Effect: 0.289, N: 200, Alpha: 0.05, Alternative: Greater }, 6) },
+      func(p PowerParams) float64 { return p.Power }, 0.8853519 },
+    { "ChiSquared N",
+      func() (PowerParams, error) { return ChiSquaredPower(PowerParams{ Effect: 0.3, Power: 0.8, Alpha: 0.05, Alternative: Greater }, 1) },
+      func(p PowerParams) float64 { return p.N }, 87.20956 },
+  }
+  for _, example := range examples {
+    params, err := example.solve()
+    if err != nil {
+      t.Fatal(err)
+    }
+    if got := example.got(params); !floatsEqual(got, example.expected, 0.00001) {
+      t.Fatalf("\n%s:\n  Expected: %f\n  Got: %f\n", example.name, example.expected, got)
+    }
+  }
+
+  if _, err := ZTestPower(PowerParams{ Effect: 0.5, Alpha: 0.05 }); err == nil {
+    t.Fatal("\nExpected an error for two unknowns.")
+  }
+  if _, err := ZTestPower(PowerParams{ Effect: -0.5, Power: 0.8, Alpha: 0.05, Alternative: Greater }); err == nil {
+    t.Fatal("\nExpected an error for an effect against the alternative.")
+  }
+  if _, err := TTestPower(PowerParams{ Effect: 0.5, Power: 0.01, Alpha: 0.05 }, OneSampleDesign); err == nil {
+    t.Fatal("\nExpected an error for power below alpha.")
+  }
+  if _, err := ChiSquaredPower(PowerParams{ Effect: 0.3, Power: 0.8, Alpha: 0.05 }, 1); err == nil {
+    t.Fatal("\nExpected an error for a two-sided chi-squared test.")
+  }
+}
@@ -0,0 +1,234 @@
+package prob
+
+import (
+  "errors"
+  "math"
+  "math/rand"
+  "testing"
+)
+
+// Hides the Quantile of the distribution it wraps.
+type noQuantile struct {
+  Distribution
+}
+
+func Test_SobolSequence(t *testing.T) {
+  expected := [][]float64{
+    { 0, 0, 0 },
+    { 0.5, 0.5, 0.5 },
+    { 0.75, 0.25, 0.25 },
+    { 0.25, 0.75, 0.75 },
+    { 0.375, 0.375, 0.625 },
+    { 0.875, 0.875, 0.125 },
+    { 0.625, 0.125, 0.875 },
+    { 0.125, 0.625, 0.375 },
+  }
+  seq, err := NewSobolSequence(3)
+  if err != nil {
+    t.Fatal(err)
+  }
+  point := make([]float64, seq.Dims())
+  for i, want := range expected {
+    seq.Next(point)
+    for d := range want {
+      if point[d] != want[d] {
+        t.Fatalf("\nPoint %d:\n  Expected: %v\n  Got: %v\n", i, want, point)
+      }
+    }
+  }
+
+  // The first 1024 points put one point in each 1/1024 slice of every
+  // coordinate and in each 1/32 by 1/32 box of the first two.
+  seq, _ = NewSobolSequence(21)
+  points := make([][]float64, 1024)
+  for i := range points {
+    points[i] = make([]float64, seq.Dims())
+    seq.Next(points[i])
+  }
+  for d := 0; d < seq.Dims(); d++ {
+    seen := make(map[int]bool)
+    for _, p := range points {
+      seen[int(p[d] * 1024)] = true
+    }
+    if len(seen) != 1024 {
+      t.Fatalf("\nDimension %d:\n  Expected: 1024 slices\n  Got: %d\n", d, len(seen))
+    }
+  }
+  boxes := make(map[[2]int]bool)
+  for _, p := range points {
+    boxes[[2]int{ int(p[0] * 32), int(p[1] * 32) }] = true
+  }
+  if len(boxes) != 1024 {
+    t.Fatalf("\nFirst two dimensions:\n  Expected: 1024 boxes\n  Got: %d\n", len(boxes))
+  }
+
+  for _, dims := range []int{ 0, 22 } {
+    if _, err := NewSobolSequence(dims); !errors.Is(err, ErrInvalidParams) {
+      t.Fatalf("\nDims %d:\n  Expected: %v\n  Got: %v\n", dims, ErrInvalidParams, err)
+    }
+  }
+}
+
+func Test_HaltonSequence(t *testing.T) {
+  expected := [][]float64{
+    { 0, 0, 0 },
+    { 1.0 / 2, 1.0 / 3, 1.0 / 5 },
+    { 1.0 / 4, 2.0 / 3, 2.0 / 5 },
+    { 3.0 / 4, 1.0 / 9, 3.0 / 5 },
+    { 1.0 / 8, 4.0 / 9, 4.0 / 5 },
+    { 5.0 / 8, 7.0 / 9, 1.0 / 25 },
+  }
+  seq, err := NewHaltonSequence(3)
+  if err != nil {
+    t.Fatal(err)
+  }
+  point := make([]float64, seq.Dims())
+  for i, want := range expected {
+    seq.Next(point)
+    for d := range want {
+      if !floatsPicoEqual(point[d], want[d]) {
+        t.Fatalf("\nPoint %d:\n  Expected: %v\n  Got: %v\n", i, want, point)
+      }
+    }
+  }
+  if _, err := NewHaltonSequence(0); !errors.Is(err, ErrInvalidParams) {
+    t.Fatalf("\n  Expected: %v\n  Got: %v\n", ErrInvalidParams, err)
+  }
+}
+
+func Test_LatinHypercube(t *testing.T) {
+  n, dims := 100, 4
+  points := LatinHypercube(n, dims, rand.New(rand.NewSource(3)))
+  if len(points) != n {
+    t.Fatalf("\n  Expected: %d points\n  Got: %d\n", n, len(points))
+  }
+  for d := 0; d < dims; d++ {
+    seen := make([]bool, n)
+    for _, p := range points {
+      stratum := int(p[d] * float64(n))
+      if stratum < 0 || stratum >= n || seen[stratum] {
+        t.Fatalf("\nDimension %d:\n  Expected: one point in stratum %d\n  Got: %v\n", d, stratum, p[d])
+      }
+      seen[stratum] = true
+    }
+  }
+}
+
+func Test_Quantile(t *testing.T) {
+  continuous := []Distribution{
+    Cauchy{ 1, 2 },
+    Exponential{ 2 },
+    Logistic{ -1, 0.5 },
+    LogNormal{ 0.5, 0.75 },
+    Pareto{ 1.5, 3 },
+    Uniform{ -2, 5 },
+    Weibull{ 1.5, 2 },
+    noQuantile{ Normal{ 3, 2 } },
+    noQuantile{ Gamma{ 2, 0.5 } },
+  }
+  discrete := []Distribution{
+    Binomial{ 20, 0.3 },
+    Binomial{ 1e4, 0.5 },
+    Geometric{ 0.2 },
+    NegBinomial{ 10, 0.5 },
+    Poisson{ 3.5 },
+    Poisson{ 1e4 },
+  }
+  ps := []float64{ 1e-9, 0.001, 0.05, 0.25, 0.5, 0.7, 0.95, 0.999, 1 - 1e-9 }
+  for _, dist := range continuous {
+    for _, p := range ps {
+      if x := Quantile(dist, p); !floatsNanoEqual(dist.Cdf(x), p) {
+        t.Fatalf("\n%#v at %v:\n  Expected: %v\n  Got: Cdf(%v) = %v\n", dist, p, p, x, dist.Cdf(x))
+      }
+    }
+  }
+  for _, dist := range discrete {
+    for _, p := range ps {
+      k := Quantile(dist, p)
+      // Allows for rounding in the Cdf, as NegBinomial{ 10, 0.5 } has a
+      // Cdf of exactly one half at 9.
+      if k != math.Floor(k) || dist.Cdf(k) < p * (1 - 1e-12) || (k > 0 && dist.Cdf(k - 1) >= p) {
+        t.Fatalf("\n%#v at %v:\n  Expected: the least k with Cdf(k) >= p\n  Got: %v\n", dist, p, k)
+      }
+    }
+    if k := Quantile(dist, 0); k != 0 {
+      t.Fatalf("\n%#v at 0:\n  Expected: 0\n  Got: %v\n", dist, k)
+    }
+  }
+  if x := Quantile(Exponential{ 2 }, 1); !math.IsInf(x, 1) {
+    t.Fatalf("\nExponential at 1:\n  Expected: %v\n  Got: %v\n", math.Inf(1), x)
+  }
+  if x := Quantile(Cauchy{ 0, 1 }, 0); !math.IsInf(x, -1) {
+    t.Fatalf("\nCauchy at 0:\n  Expected: %v\n  Got: %v\n", math.Inf(-1), x)
+  }
+  if x := Quantile(Binomial{ 20, 0.3 }, 1); x != 20 {
+    t.Fatalf("\nBinomial at 1:\n  Expected: %v\n  Got: %v\n", 20, x)
+  }
+}
+
+func Test_SampleWith(t *testing.T) {
+  dist := Normal{ 3, 2 }
+  n := 4096
+  rng := rand.New(rand.NewSource(11))
+  // Randomized quasi-Monte Carlo and stratification estimate the mean to
+  // far better than the standard error of independent draws.
+  misses := map[Sampling]float64{}
+  for _, sampling := range []Sampling{ RandomSampling, SobolSampling, HaltonSampling, LatinHypercubeSampling, StratifiedSampling, AntitheticSampling } {
+    worst := 0.0
+    for trial := 0; trial < 10; trial++ {
+      samples, err := SampleWith(dist, n, sampling, rng)
+      if err != nil {
+        t.Fatal(err)
+      }
+      if len(samples) != n {
+        t.Fatalf("\nSampling %d:\n  Expected: %d samples\n  Got: %d\n", sampling, n, len(samples))
+      }
+      mean := 0.0
+      for _, x := range samples {
+        if math.IsNaN(x) || math.IsInf(x, 0) {
+          t.Fatalf("\nSampling %d:\n  Expected: finite samples\n  Got: %v\n", sampling, x)
+        }
+        mean += x / float64(n)
+      }
+      worst = math.Max(worst, math.Abs(mean - dist.Mu))
+    }
+    misses[sampling] = worst
+  }
+  standardError := dist.Sigma / math.Sqrt(float64(n))
+  if misses[RandomSampling] > 5 * standardError {
+    t.Fatalf("\nRandom sampling:\n  Expected: mean within %v\n  Got: off by %v\n", 5 * standardError, misses[RandomSampling])
+  }
+  for _, sampling := range []Sampling{ SobolSampling, HaltonSampling, LatinHypercubeSampling, StratifiedSampling, AntitheticSampling } {
+    if misses[sampling] > standardError / 10 {
+      t.Fatalf("\nSampling %d:\n  Expected: mean within %v\n  Got: off by %v\n", sampling, standardError / 10, misses[sampling])
+    }
+  }
+
+  empirical, _ := NewWeightedEmpirical([]float64{ 1, 2, 5 }, []float64{ 1, 0, 3 })
+  samples, err := SampleWith(empirical, 400, StratifiedSampling, rng)
+  if err != nil {
+    t.Fatal(err)
+  }
+  counts := map[float64]int{}
+  for _, x := range samples {
+    counts[x]++
+  }
+  if counts[1] != 100 || counts[5] != 300 || len(counts) != 2 {
+    t.Fatalf("\nStratified Empirical:\n  Expected: 100 ones and 300 fives\n  Got: %v\n", counts)
+  }
+
+  if _, err := SampleWith(Normal{ 0, -1 }, 10, SobolSampling, nil); !errors.Is(err, ErrInvalidParams) {
+    t.Fatalf("\n  Expected: %v\n  Got: %v\n", ErrInvalidParams, err)
+  }
+  message := "SampleWith: Sampling must be one of the defined samplings, got -1."
+  if _, err := SampleWith(dist, 10, Sampling(-1), nil); !errors.Is(err, ErrInvalidParams) || err.Error() != message {
+    t.Fatalf("\n  Expected: %v\n  Got: %v\n", message, err)
+  }
+}
+
+func Benchmark_SampleWith_Sobol(b *testing.B) {
+  dist := Normal{ 0, 1 }
+  for i := 0; i < b.N; i++ {
+    SampleWith(dist, 1024, SobolSampling, nil)
+  }
+}
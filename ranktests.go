@@ -0,0 +1,331 @@
+package prob
+
+import (
+  "math"
+  "sort"
+)
+
+const (
+  // Exact null distributions are used below this many observations per
+  // sample when there are no ties, as in R's wilcox.test.
+  rank_exact_cutoff = 50
+  // Kruskal–Wallis is only enumerated exactly up to this many arrangements.
+  kruskal_exact_limit = 1000000
+)
+
+// Mid-ranks of the values, starting from one, and the tie correction
+// Σ(t³ - t) over groups of t tied values.
+func ranks(values []float64) ([]float64, float64) {
+  index := make([]int, len(values))
+  for i := range index {
+    index[i] = i
+  }
+  sort.Slice(index, func(i, j int) bool { return values[index[i]] < values[index[j]] })
+  result := make([]float64, len(values))
+  ties := 0.0
+  for i := 0; i < len(index); {
+    j := i + 1
+    for j < len(index) && values[index[j]] == values[index[i]] {
+      j++
+    }
+    rank := float64(i + j + 1) / 2
+    for k := i; k < j; k++ {
+      result[index[k]] = rank
+    }
+    t := float64(j - i)
+    ties += (t * t * t) - t
+    i = j
+  }
+  return result, ties
+}
+
+// The p-value of an integer statistic from its exact null probabilities,
+// following R's convention for the two-sided case.
+func exactPValue(probs []float64, statistic float64, alt Alternative) float64 {
+  s := int(math.Floor(statistic + 0.5))
+  lower, upper := 0.0, 0.0
+  for i, p := range probs {
+    if i <= s {
+      lower += p
+    }
+    if i >= s {
+      upper += p
+    }
+  }
+  switch alt {
+  case Less:
+    return math.Min(lower, 1.0)
+  case Greater:
+    return math.Min(upper, 1.0)
+  }
+  if statistic > float64(len(probs) - 1) / 2 {
+    return math.Min(2 * upper, 1.0)
+  }
+  return math.Min(2 * lower, 1.0)
+}
+
+// The p-value of a normally approximated statistic with a continuity
+// correction of one half.
+func normalPValue(statistic, mean, sigma float64, alt Alternative) float64 {
+  diff := statistic - mean
+  correction := 0.5
+  switch alt {
+  case Less:
+    correction = -0.5
+  case TwoSided:
+    if diff < 0 {
+      correction = -0.5
+    }
+  }
+  z := (diff - correction) / sigma
+  return pValue(Normal{ 0, 1 }.Cdf, z, alt)
+}
+
+func rankResult(statistic, p float64, alt Alternative) TestResult {
+  return TestResult{
+    Statistic:    statistic,
+    Degrees:      math.NaN(),
+    Degrees2:     math.NaN(),
+    PValue:       p,
+    Alternative:  alt,
+    Estimate:     math.NaN(),
+    Lower:        math.NaN(),
+    Upper:        math.NaN(),
+  }
+}
+
+// The Mann–Whitney U test (Wilcoxon rank-sum test) that x and y come from
+// the same distribution, against a location shift of x relative to y. The
+// statistic is U for x. Uses the exact null distribution for small samples
+// without ties and otherwise a normal approximation with tie and continuity
+// corrections.
+//
+// See: https://en.wikipedia.org/wiki/Mann%E2%80%93Whitney_U_test
+func MannWhitneyUTest(x, y []float64, alt Alternative) (TestResult, error) {
+  if err := validateAlternative(alt); err != nil {
+    return TestResult{}, err
+  }
+  if len(x) < 1 || len(y) < 1 {
+    return TestResult{}, InvalidParamsError{ S: "Samples must not be empty." }
+  }
+  all := append(append([]float64{}, x...), y...)
+  r, ties := ranks(all)
+  m, n := float64(len(x)), float64(len(y))
+  sum := 0.0
+  for i := range x {
+    sum += r[i]
+  }
+  u := sum - (m * (m + 1) / 2)
+  if ties == 0 && len(x) < rank_exact_cutoff && len(y) < rank_exact_cutoff {
+    return rankResult(u, exactPValue(mannWhitneyNull(len(x), len(y)), u, alt), alt), nil
+  }
+  total := m + n
+  sigma := math.Sqrt(m * n / 12 * ((total + 1) - (ties / (total * (total - 1)))))
+  return rankResult(u, normalPValue(u, m * n / 2, sigma, alt), alt), nil
+}
+
+// Null probabilities of U for samples of size m and n, from the counts of
+// m-subsets of the ranks 1..m+n by their sum.
+func mannWhitneyNull(m, n int) []float64 {
+  maxSum := m * (m + n)
+  counts := make([][]float64, m + 1)
+  for k := range counts {
+    counts[k] = make([]float64, maxSum + 1)
+  }
+  counts[0][0] = 1
+  for e := 1; e <= m + n; e++ {
+    for k := m; k >= 1; k-- {
+      if k > e {
+        continue
+      }
+      for s := maxSum; s >= e; s-- {
+        counts[k][s] += counts[k-1][s-e]
+      }
+    }
+  }
+  offset := m * (m + 1) / 2
+  result := make([]float64, (m * n) + 1)
+  total := 0.0
+  for u := range result {
+    result[u] = counts[m][u + offset]
+    total += result[u]
+  }
+  for u := range result {
+    result[u] /= total
+  }
+  return result
+}
+
+// The Wilcoxon signed-rank test that x - mu is symmetric about zero. Zero
+// differences are dropped. The statistic V is the sum of the ranks of the
+// positive differences. Uses the exact null distribution for small samples
+// without ties or zeros and otherwise a normal approximation with tie and
+// continuity corrections.
+//
+// See: https://en.wikipedia.org/wiki/Wilcoxon_signed-rank_test
+func WilcoxonSignedRankTest(x []float64, mu float64, alt Alternative) (TestResult, error) {
+  if err := validateAlternative(alt); err != nil {
+    return TestResult{}, err
+  }
+  diff := make([]float64, 0, len(x))
+  for _, value := range x {
+    if value != mu {
+      diff = append(diff, value - mu)
+    }
+  }
+  if len(diff) < 1 {
+    return TestResult{}, InvalidParamsError{ S: "Samples must contain a value other than Mu." }
+  }
+  abs := make([]float64, len(diff))
+  for i, d := range diff {
+    abs[i] = math.Abs(d)
+  }
+  r, ties := ranks(abs)
+  v := 0.0
+  for i, d := range diff {
+    if d > 0 {
+      v += r[i]
+    }
+  }
+  n := float64(len(diff))
+  if ties == 0 && len(diff) == len(x) && len(diff) < rank_exact_cutoff {
+    return rankResult(v, exactPValue(signedRankNull(len(diff)), v, alt), alt), nil
+  }
+  sigma := math.Sqrt((n * (n + 1) * ((2 * n) + 1) / 24) - (ties / 48))
+  return rankResult(v, normalPValue(v, n * (n + 1) / 4, sigma, alt), alt), nil
+}
+
+// The paired form of the Wilcoxon signed-rank test, on the differences x - y.
+func WilcoxonPairedTest(x, y []float64, mu float64, alt Alternative) (TestResult, error) {
+  if len(x) != len(y) {
+    return TestResult{}, InvalidParamsError{ S: "Paired samples must be the same length." }
+  }
+  diff := make([]float64, len(x))
+  for i := range x {
+    diff[i] = x[i] - y[i]
+  }
+  return WilcoxonSignedRankTest(diff, mu, alt)
+}
+
+// Null probabilities of V for n differences, from the counts of subsets of
+// the ranks 1..n by their sum.
+func signedRankNull(n int) []float64 {
+  maxSum := n * (n + 1) / 2
+  result := make([]float64, maxSum + 1)
+  result[0] = 1
+  for e := 1; e <= n; e++ {
+    for s := maxSum; s >= e; s-- {
+      result[s] += result[s-e]
+    }
+  }
+  scale := math.Pow(2, -float64(n))
+  for s := range result {
+    result[s] *= scale
+  }
+  return result
+}
+
+// The Kruskal–Wallis test that the groups come from the same distribution.
+// The statistic H is corrected for ties. The p-value is exact, by
+// enumerating rank arrangements, for small designs without ties and
+// otherwise uses the chi-squared approximation with k - 1 degrees of freedom.
+//
+// See: https://en.wikipedia.org/wiki/Kruskal%E2%80%93Wallis_one-way_analysis_of_variance
+func KruskalWallisTest(groups ...[]float64) (TestResult, error) {
+  if len(groups) < 2 {
+    return TestResult{}, InvalidParamsError{ S: "There must be at least two groups." }
+  }
+  all := []float64{}
+  sizes := make([]int, len(groups))
+  for i, group := range groups {
+    if len(group) < 1 {
+      return TestResult{}, InvalidParamsError{ S: "Groups must not be empty." }
+    }
+    sizes[i] = len(group)
+    all = append(all, group...)
+  }
+  r, ties := ranks(all)
+  total := float64(len(all))
+  if ties == (total * total * total) - total {
+    return TestResult{}, InvalidParamsError{ S: "Samples must not all be equal." }
+  }
+  sums := make([]float64, len(groups))
+  offset := 0
+  for i, size := range sizes {
+    for j := 0; j < size; j++ {
+      sums[i] += r[offset + j]
+    }
+    offset += size
+  }
+  score := kruskalScore(sums, sizes)
+  h := ((12 / (total * (total + 1)) * score) - (3 * (total + 1))) / (1 - (ties / ((total * total * total) - total)))
+  degrees := float64(len(groups) - 1)
+  var p float64
+  if ties == 0 && kruskalArrangements(sizes) <= kruskal_exact_limit {
+    p = kruskalExact(sizes, score)
+  } else {
+    p = 1 - ChiSquared{ degrees }.Cdf(h)
+  }
+  result := rankResult(h, p, Greater)
+  result.Degrees = degrees
+  return result, nil
+}
+
+// Σ R²/n, which orders arrangements the same way H does.
+func kruskalScore(sums []float64, sizes []int) float64 {
+  score := 0.0
+  for i, sum := range sums {
+    score += sum * sum / float64(sizes[i])
+  }
+  return score
+}
+
+// The multinomial coefficient N! / Π n!, or +Inf once it passes the limit.
+func kruskalArrangements(sizes []int) float64 {
+  result := 1.0
+  placed := 0
+  for _, size := range sizes {
+    for j := 1; j <= size; j++ {
+      placed++
+      result = result * float64(placed) / float64(j)
+    }
+    if result > kruskal_exact_limit {
+      return math.Inf(1)
+    }
+  }
+  return result
+}
+
+// The fraction of rank arrangements whose score is at least the observed one.
+func kruskalExact(sizes []int, observed float64) float64 {
+  total := 0
+  for _, size := range sizes {
+    total += size
+  }
+  remaining := append([]int{}, sizes...)
+  sums := make([]float64, len(sizes))
+  tolerance := observed * 1e-12
+  count, extreme := 0.0, 0.0
+  var assign func(rank int)
+  assign = func(rank int) {
+    if rank > total {
+      count++
+      if kruskalScore(sums, sizes) >= observed - tolerance {
+        extreme++
+      }
+      return
+    }
+    for i := range remaining {
+      if remaining[i] == 0 {
+        continue
+      }
+      remaining[i]--
+      sums[i] += float64(rank)
+      assign(rank + 1)
+      sums[i] -= float64(rank)
+      remaining[i]++
+    }
+  }
+  assign(1)
+  return extreme / count
+}
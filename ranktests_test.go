@@ -0,0 +1,84 @@
+package prob
+
+import (
+  "testing"
+)
+
+// Depression scores from Hollander & Wolfe, as in R's ?wilcox.test.
+var (
+  depressionBefore = []float64{ 1.83, 0.50, 1.62, 2.48, 1.68, 1.88, 1.55, 3.06, 1.30 }
+  depressionAfter = []float64{ 0.878, 0.647, 0.598, 2.05, 1.06, 1.29, 1.06, 3.14, 1.29 }
+)
+
+type rankTest struct {
+  result      TestResult
+  statistic   float64
+  pValue      float64
+}
+
+// Test against R's wilcox.test and brute-force enumeration of the null.
+func Test_RankTests(t *testing.T) {
+  results := []rankTest{}
+  add := func(statistic, pValue float64) func(TestResult, error) {
+    return func(result TestResult, err error) {
+      if err != nil {
+        t.Fatal(err)
+      }
+      results = append(results, rankTest{ result, statistic, pValue })
+    }
+  }
+  // Ties, so normal approximation.
+  add(25.5, 0.06932757543362666)(MannWhitneyUTest(sleepGroup1, sleepGroup2, TwoSided))
+  add(0.0, 0.009090698015925103)(WilcoxonPairedTest(sleepGroup1, sleepGroup2, 0, TwoSided))
+  // No ties, so exact.
+  add(58.0, 0.1329194581853188)(MannWhitneyUTest(depressionBefore, depressionAfter, TwoSided))
+  add(58.0, 0.0664597290926594)(MannWhitneyUTest(depressionBefore, depressionAfter, Greater))
+  add(58.0, 0.9442044002897128)(MannWhitneyUTest(depressionBefore, depressionAfter, Less))
+  add(40.0, 0.0390625)(WilcoxonPairedTest(depressionBefore, depressionAfter, 0, TwoSided))
+  add(40.0, 0.01953125)(WilcoxonPairedTest(depressionBefore, depressionAfter, 0, Greater))
+  for _, example := range results {
+    if !floatsPicoEqual(example.result.Statistic, example.statistic) {
+      t.Fatalf("\nStatistic:\n  Expected: %f\n  Got: %f\n", example.statistic, example.result.Statistic)
+    }
+    if !floatsNanoEqual(example.result.PValue, example.pValue) {
+      t.Fatalf("\nPValue:\n  Expected: %f\n  Got: %f\n", example.pValue, example.result.PValue)
+    }
+  }
+
+  if _, err := WilcoxonSignedRankTest([]float64{ 1.0, 1.0 }, 1.0, TwoSided); err == nil {
+    t.Fatal("\nExpected an error when every value equals Mu.")
+  }
+  if _, err := MannWhitneyUTest(sleepGroup1, nil, TwoSided); err == nil {
+    t.Fatal("\nExpected an error for an empty sample.")
+  }
+}
+
+func Test_RankTests_KruskalWallis(t *testing.T) {
+  // No ties, so exact.
+  result, err := KruskalWallisTest(
+    []float64{ 2.9, 3.0, 2.5, 2.6, 3.2 },
+    []float64{ 3.8, 2.7, 4.0, 2.4 },
+    []float64{ 2.8, 3.4, 3.7, 2.2, 2.0 },
+  )
+  if err != nil {
+    t.Fatal(err)
+  }
+  if !floatsPicoEqual(result.Statistic, 0.7714285714285722) || !floatsNanoEqual(result.PValue, 0.7107733536304965) || result.Degrees != 2 {
+    t.Fatalf("\nExpected: %f, %f\nGot: %f, %f\n", 0.7714285714285722, 0.7107733536304965, result.Statistic, result.PValue)
+  }
+  // Ties, so chi-squared approximation.
+  result, err = KruskalWallisTest(
+    []float64{ 1, 2, 2, 3, 5, 6 },
+    []float64{ 2, 4, 4, 7, 8, 9, 9 },
+    []float64{ 5, 6, 6, 8, 10, 11 },
+  )
+  if err != nil {
+    t.Fatal(err)
+  }
+  if !floatsPicoEqual(result.Statistic, 7.087575987841956) || !floatsNanoEqual(result.PValue, 0.02890363266763624) {
+    t.Fatalf("\nExpected: %f, %f\nGot: %f, %f\n", 7.087575987841956, 0.02890363266763624, result.Statistic, result.PValue)
+  }
+  if _, err := KruskalWallisTest([]float64{ 1.0, 2.0 }); err == nil {
+    t.Fatal("\nExpected an error for a single group.")
+  }
+}
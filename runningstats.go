@@ -0,0 +1,153 @@
+package prob
+
+import (
+  "math"
+)
+
+// RunningStats accumulates descriptive statistics over a stream of values in
+// a single pass, optionally weighted. Partial accumulators, e.g. from parallel
+// workers, can be combined with Merge. The zero value is ready to use.
+//
+// Moments are those of the weighted empirical distribution of the values, so
+// they agree with Empirical; Kurtosis is the excess kurtosis.
+//
+// See: https://en.wikipedia.org/wiki/Algorithms_for_calculating_variance#Higher-order_statistics
+type RunningStats struct {
+  count   int
+  weight  float64
+  mean    float64
+  m2      float64
+  m3      float64
+  m4      float64
+  min     float64
+  max     float64
+}
+
+// Adds a single value with weight one.
+func (s *RunningStats) Add(x float64) {
+  s.AddWeighted(x, 1.0)
+}
+
+// Adds each of the values with weight one.
+func (s *RunningStats) AddAll(xs []float64) {
+  for _, x := range xs {
+    s.AddWeighted(x, 1.0)
+  }
+}
+
+// Adds a value with the given frequency or reliability weight. Non-positive
+// weights are ignored.
+func (s *RunningStats) AddWeighted(x, w float64) {
+  if !(w > 0) {
+    return
+  }
+  s.Merge(RunningStats{ count: 1, weight: w, mean: x, min: x, max: x })
+}
+
+// Combines another accumulator into this one.
+// Ref: Pébay (2008), Formulas for robust, one-pass parallel computation of
+// covariances and arbitrary-order statistical moments.
+func (s *RunningStats) Merge(other RunningStats) {
+  if other.count == 0 {
+    return
+  }
+  if s.count == 0 {
+    *s = other
+    return
+  }
+  na, nb := s.weight, other.weight
+  n := na + nb
+  delta := other.mean - s.mean
+  d2 := delta * delta
+  m2 := s.m2 + other.m2 + (d2 * na * nb / n)
+  m3 := s.m3 + other.m3 + (d2 * delta * na * nb * (na - nb) / (n * n)) +
+    (3 * delta * ((na * other.m2) - (nb * s.m2)) / n)
+  m4 := s.m4 + other.m4 + (d2 * d2 * na * nb * ((na * na) - (na * nb) + (nb * nb)) / (n * n * n)) +
+    (6 * d2 * ((na * na * other.m2) + (nb * nb * s.m2)) / (n * n)) +
+    (4 * delta * ((na * other.m3) - (nb * s.m3)) / n)
+  s.mean += delta * nb / n
+  s.m2, s.m3, s.m4 = m2, m3, m4
+  s.weight = n
+  s.count += other.count
+  s.min = math.Min(s.min, other.min)
+  s.max = math.Max(s.max, other.max)
+}
+
+// The number of values added.
+func (s RunningStats) Count() int {
+  return s.count
+}
+
+// The total weight of the values added.
+func (s RunningStats) Weight() float64 {
+  return s.weight
+}
+
+func (s RunningStats) Mean() float64 {
+  if s.count == 0 {
+    return math.NaN()
+  }
+  return s.mean
+}
+
+// The variance of the values, dividing by the total weight.
+func (s RunningStats) Variance() float64 {
+  if s.count == 0 {
+    return math.NaN()
+  }
+  result := s.m2 / s.weight
+  return result
+}
+
+// The unbiased sample variance, treating weights as frequencies.
+func (s RunningStats) SampleVariance() float64 {
+  if s.weight <= 1 {
+    return math.NaN()
+  }
+  result := s.m2 / (s.weight - 1)
+  return result
+}
+
+func (s RunningStats) Skewness() float64 {
+  if s.count == 0 {
+    return math.NaN()
+  }
+  result := math.Sqrt(s.weight) * s.m3 / math.Pow(s.m2, 1.5)
+  return result
+}
+
+// Returns the excess kurtosis.
+func (s RunningStats) Kurtosis() float64 {
+  if s.count == 0 {
+    return math.NaN()
+  }
+  result := (s.weight * s.m4 / (s.m2 * s.m2)) - 3
+  return result
+}
+
+func (s RunningStats) StdDev() float64 {
+  variance := s.Variance()
+  result := math.Sqrt(variance)
+  return result
+}
+
+func (s RunningStats) RelStdDev() float64 {
+  mean := s.Mean()
+  stdDev := s.StdDev()
+  result := stdDev / mean
+  return result
+}
+
+func (s RunningStats) Min() float64 {
+  if s.count == 0 {
+    return math.NaN()
+  }
+  return s.min
+}
+
+func (s RunningStats) Max() float64 {
+  if s.count == 0 {
+    return math.NaN()
+  }
+  return s.max
+}
@@ -0,0 +1,84 @@
+package prob
+
+import (
+  "fmt"
+  "math"
+  "testing"
+)
+
+// Checks an accumulator against the moments of the equivalent Empirical.
+func checkRunningStats(stats RunningStats, dist Empirical) error {
+  pairs := []struct{ name string; got, expected float64 }{
+    { "Mean",       stats.Mean(),       dist.Mean() },
+    { "Variance",   stats.Variance(),   dist.Variance() },
+    { "StdDev",     stats.StdDev(),     dist.StdDev() },
+    { "RelStdDev",  stats.RelStdDev(),  dist.RelStdDev() },
+    { "Skewness",   stats.Skewness(),   dist.Skewness() },
+    { "Kurtosis",   stats.Kurtosis(),   dist.Kurtosis() },
+    { "Min",        stats.Min(),        dist.Values[0] },
+    { "Max",        stats.Max(),        dist.Values[len(dist.Values)-1] },
+  }
+  for _, pair := range pairs {
+    if !floatsPicoEqual(pair.got, pair.expected) {
+      return fmt.Errorf("\n%s:\n  Expected: %f\n  Got: %f\n", pair.name, pair.expected, pair.got)
+    }
+  }
+  return nil
+}
+
+func Test_RunningStats(t *testing.T) {
+  var stats RunningStats
+  if !math.IsNaN(stats.Mean()) || !math.IsNaN(stats.Min()) || stats.Count() != 0 {
+    t.Fatal("\nExpected an empty accumulator to report NaN.")
+  }
+
+  values := []float64{ 10.0, 3.0, 1.0, 4.0, 2.0 }
+  stats.AddAll(values)
+  dist, _ := NewEmpirical(values)
+  if err := checkRunningStats(stats, dist); err != nil {
+    t.Fatal(err)
+  }
+  if stats.Count() != 5 || stats.Weight() != 5.0 {
+    t.Fatalf("\nCount: %d\nWeight: %f\n", stats.Count(), stats.Weight())
+  }
+  if !floatsPicoEqual(stats.SampleVariance(), 12.5) {
+    t.Fatalf("\nSampleVariance:\n  Expected: %f\n  Got: %f\n", 12.5, stats.SampleVariance())
+  }
+
+  weights := []float64{ 4.0, 1.0, 3.0, 2.0 }
+  values = []float64{ 5.0, 0.0, 2.0, 1.0 }
+  var weighted RunningStats
+  for i := range values {
+    weighted.AddWeighted(values[i], weights[i])
+  }
+  weighted.AddWeighted(100.0, 0.0)
+  dist, _ = NewWeightedEmpirical(values, weights)
+  if err := checkRunningStats(weighted, dist); err != nil {
+    t.Fatal(err)
+  }
+}
+
+func Test_RunningStats_Merge(t *testing.T) {
+  samples, _ := Sample(Gamma{ 2.0, 0.5 }, 10000)
+  var whole RunningStats
+  whole.AddAll(samples)
+  parts := make([]RunningStats, 4)
+  for i, x := range samples {
+    parts[i % len(parts)].Add(x)
+  }
+  var merged RunningStats
+  for _, part := range parts {
+    merged.Merge(part)
+  }
+  merged.Merge(RunningStats{})
+  if merged.Count() != whole.Count() {
+    t.Fatalf("\nCount:\n  Expected: %d\n  Got: %d\n", whole.Count(), merged.Count())
+  }
+  dist, _ := NewEmpirical(samples)
+  if err := checkRunningStats(merged, dist); err != nil {
+    t.Fatal(err)
+  }
+  if err := checkRunningStats(whole, dist); err != nil {
+    t.Fatal(err)
+  }
+}
@@ -0,0 +1,187 @@
+package prob
+
+import (
+  "errors"
+  "fmt"
+  "math"
+  "math/rand"
+  "runtime"
+  "sync"
+  "sync/atomic"
+)
+
+// Samples are drawn in blocks of this size by SampleParallel, each from its
+// own stream.
+const sample_block = 1 << 16
+
+// Returned, wrapped with the index of the draw, when a distribution draws NaN.
+var ErrNaNSample = errors.New("distribution drew NaN")
+
+// Fills dst with samples from a distribution drawn from rng, or from the
+// global source in math/rand when rng is nil.
+func SampleInto(dist Distribution, dst []float64, rng *rand.Rand) error {
+  if err := dist.Validate(); err != nil {
+    return err
+  }
+  return sampleInto(dist, dst, rng, 0)
+}
+
+func sampleInto(dist Distribution, dst []float64, rng *rand.Rand, offset int) error {
+  // The ziggurats fill two draws from each value of the source.
+  switch d := dist.(type) {
+  case Normal:
+    normalSampler{ d.Mu, d.Sigma }.fill(dst, rng)
+    return nil
+  case Exponential:
+    exponentialSampler{ d.Lambda }.fill(dst, rng)
+    return nil
+  }
+  for i := range dst {
+    value := dist.RandomWith(rng)
+    if math.IsNaN(value) {
+      return fmt.Errorf("draw %d: %w", offset + i, ErrNaNSample)
+    }
+    dst[i] = value
+  }
+  return nil
+}
+
+// Takes n samples from a distribution on the given number of goroutines, or
+// on GOMAXPROCS of them when workers is not positive. Every block of samples
+// comes from its own stream derived from seed and the block's index, so the
+// result depends only on seed and n and not on the number of workers.
+func SampleParallel(dist Distribution, n, workers int, seed int64) ([]float64, error) {
+  if err := dist.Validate(); err != nil {
+    return nil, err
+  }
+  if n <= 0 {
+    return []float64{}, nil
+  }
+  blocks := (n + sample_block - 1) / sample_block
+  if workers <= 0 {
+    workers = runtime.GOMAXPROCS(0)
+  }
+  if workers > blocks {
+    workers = blocks
+  }
+  result := make([]float64, n)
+  errs := make([]error, blocks)
+  next := int64(-1)
+  var wg sync.WaitGroup
+  for w := 0; w < workers; w++ {
+    wg.Add(1)
+    go func() {
+      defer wg.Done()
+      for {
+        block := int(atomic.AddInt64(&next, 1))
+        if block >= blocks {
+          return
+        }
+        lo := block * sample_block
+        hi := lo + sample_block
+        if hi > n {
+          hi = n
+        }
+        errs[block] = sampleInto(dist, result[lo:hi], newStream(seed, block), lo)
+      }
+    }()
+  }
+  wg.Wait()
+  for _, err := range errs {
+    if err != nil {
+      return nil, err
+    }
+  }
+  return result, nil
+}
+
+func randFloat64(r *rand.Rand) float64 {
+  if r == nil {
+    return rand.Float64()
+  }
+  return r.Float64()
+}
+
+func randNormFloat64(r *rand.Rand) float64 {
+  if r == nil {
+    return rand.NormFloat64()
+  }
+  return r.NormFloat64()
+}
+
+func randExpFloat64(r *rand.Rand) float64 {
+  if r == nil {
+    return rand.ExpFloat64()
+  }
+  return r.ExpFloat64()
+}
+
+func randIntn(r *rand.Rand, n int) int {
+  if r == nil {
+    return rand.Intn(n)
+  }
+  return r.Intn(n)
+}
+
+func randUint64(r *rand.Rand) uint64 {
+  if r == nil {
+    return rand.Uint64()
+  }
+  return r.Uint64()
+}
+
+func randPerm(r *rand.Rand, n int) []int {
+  if r == nil {
+    return rand.Perm(n)
+  }
+  return r.Perm(n)
+}
+
+// A source for the given stream of seed. The default source in math/rand
+// reduces its seed modulo 2³¹ - 1, which is too few for independent streams,
+// so streams use xoshiro256** with its whole state seeded by SplitMix64.
+func newStream(seed int64, stream int) *rand.Rand {
+  source := &xoshiro{}
+  source.Seed(int64(splitmix64(uint64(seed)) ^ uint64(stream)))
+  return rand.New(source)
+}
+
+func splitmix64(z uint64) uint64 {
+  z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+  z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+  return z ^ (z >> 31)
+}
+
+// Ref: https://prng.di.unimi.it/xoshiro256starstar.c
+type xoshiro struct {
+  s [4]uint64
+}
+
+func (x *xoshiro) Seed(seed int64) {
+  z := uint64(seed)
+  for i := range x.s {
+    z += 0x9e3779b97f4a7c15
+    x.s[i] = splitmix64(z)
+  }
+}
+
+func (x *xoshiro) Uint64() uint64 {
+  s := &x.s
+  result := rotl(s[1] * 5, 7) * 9
+  t := s[1] << 17
+  s[2] ^= s[0]
+  s[3] ^= s[1]
+  s[1] ^= s[2]
+  s[0] ^= s[3]
+  s[2] ^= t
+  s[3] = rotl(s[3], 45)
+  return result
+}
+
+func (x *xoshiro) Int63() int64 {
+  return int64(x.Uint64() >> 1)
+}
+
+func rotl(x uint64, k uint) uint64 {
+  return (x << k) | (x >> (64 - k))
+}
@@ -0,0 +1,100 @@
+package prob
+
+import (
+  "errors"
+  "math"
+  "math/rand"
+  "testing"
+)
+
+// Draws NaN after the given number of draws.
+type nanAfter struct {
+  Normal
+  draws *int
+}
+
+func (dist nanAfter) RandomWith(r *rand.Rand) float64 {
+  if *dist.draws == 0 {
+    return math.NaN()
+  }
+  *dist.draws--
+  return dist.Normal.RandomWith(r)
+}
+
+func Test_SampleInto(t *testing.T) {
+  dist := Gamma{ 2.0, 0.5 }
+  first := make([]float64, 1000)
+  second := make([]float64, 1000)
+  if err := SampleInto(dist, first, rand.New(rand.NewSource(7))); err != nil {
+    t.Fatal(err)
+  }
+  if err := SampleInto(dist, second, rand.New(rand.NewSource(7))); err != nil {
+    t.Fatal(err)
+  }
+  for i := range first {
+    if first[i] != second[i] {
+      t.Fatalf("\nDraw %d:\n  Expected: %f\n  Got: %f\n", i, first[i], second[i])
+    }
+  }
+
+  draws := 5
+  err := SampleInto(nanAfter{ Normal{ 0, 1 }, &draws }, first, nil)
+  if !errors.Is(err, ErrNaNSample) || err.Error() != "draw 5: distribution drew NaN" {
+    t.Fatalf("\n  Expected: draw 5: %v\n  Got: %v\n", ErrNaNSample, err)
+  }
+  // Invalid distributions fail even when there is nothing to draw.
+  for _, n := range []int{ 10, 0 } {
+    if _, err := Sample(Normal{ 0, -1 }, n); !errors.Is(err, ErrInvalidParams) {
+      t.Fatalf("\nSample %d:\n  Expected: %v\n  Got: %v\n", n, ErrInvalidParams, err)
+    }
+    if _, err := SampleParallel(Normal{ 0, -1 }, n, 2, 1); !errors.Is(err, ErrInvalidParams) {
+      t.Fatalf("\nSampleParallel %d:\n  Expected: %v\n  Got: %v\n", n, ErrInvalidParams, err)
+    }
+  }
+}
+
+func Test_SampleParallel(t *testing.T) {
+  dist := Exponential{ 2.0 }
+  n := (3 * sample_block) + 5
+  one, err := SampleParallel(dist, n, 1, 42)
+  if err != nil {
+    t.Fatal(err)
+  }
+  many, err := SampleParallel(dist, n, 8, 42)
+  if err != nil {
+    t.Fatal(err)
+  }
+  other, _ := SampleParallel(dist, n, 8, 43)
+  mean, same := 0.0, 0
+  for i := range one {
+    if one[i] != many[i] {
+      t.Fatalf("\nDraw %d:\n  Expected: %f\n  Got: %f\n", i, one[i], many[i])
+    }
+    if one[i] == other[i] {
+      same++
+    }
+    mean += one[i] / float64(n)
+  }
+  if same > 0 {
+    t.Fatalf("\nSeeds 42 and 43 share %d draws.\n", same)
+  }
+  // Four standard errors of the sample mean.
+  if math.Abs(mean - dist.Mean()) > 4 * dist.StdDev() / math.Sqrt(float64(n)) {
+    t.Fatalf("\nSample average:\n  Expected: %f\n  Got: %f\n", dist.Mean(), mean)
+  }
+
+  draws := sample_block + 3
+  if _, err := SampleParallel(nanAfter{ Normal{ 0, 1 }, &draws }, 2 * sample_block, 1, 1); !errors.Is(err, ErrNaNSample) {
+    t.Fatalf("\n  Expected: %v\n  Got: %v\n", ErrNaNSample, err)
+  }
+  if _, err := SampleParallel(Exponential{ -1 }, 10, 2, 1); !errors.Is(err, ErrInvalidParams) {
+    t.Fatalf("\n  Expected: %v\n  Got: %v\n", ErrInvalidParams, err)
+  }
+}
+
+func Benchmark_SampleParallel(b *testing.B) {
+  dist := Normal{ 0.0, 1.0 }
+  for n := 0; n < b.N; n++ {
+    SampleParallel(dist, 1 << 20, 0, int64(n))
+  }
+}
@@ -0,0 +1,553 @@
+package prob
+
+import (
+  "math"
+  "math/rand"
+)
+
+const (
+  zig_layers = 256
+  zig_normal_r = 3.6541528853610088
+  zig_exponential_r = 7.69711747013104972
+  btpe_cutoff = 30
+  ptrs_cutoff = 10
+)
+
+// A Sampler draws random values like a distribution's Random and RandomWith.
+//
+// See: NewSampler
+type Sampler interface {
+  Random()                float64
+  RandomWith(*rand.Rand)  float64
+}
+
+// Prepares a sampler for a distribution, computing its setup once so that
+// sampling is cheaper than through the distribution's own Random:
+//
+//   - Normal and Exponential use 256 layer ziggurats. A single draw costs
+//     about what math/rand's does, but SampleInto and SampleParallel take
+//     two draws from each value of the source.
+//   - Poisson uses Hörmann's PTRS for μ >= 10.
+//   - Binomial uses a table of its Cdf for np < 30 and the full BTPE,
+//     squeezes included, above it.
+//   - Weighted Empirical uses Walker's alias table.
+//
+// Other distributions sample through their own RandomWith. Prepared
+// samplers never change after they are made, so goroutines can share one
+// as long as each passes its own *rand.Rand.
+func NewSampler(dist Distribution) (Sampler, error) {
+  if err := dist.Validate(); err != nil {
+    return nil, err
+  }
+  switch d := dist.(type) {
+  case Normal:
+    return normalSampler{ d.Mu, d.Sigma }, nil
+  case Exponential:
+    return exponentialSampler{ d.Lambda }, nil
+  case Poisson:
+    return newPoissonSampler(d.Mu), nil
+  case Binomial:
+    return newBinomialSampler(d.Trials, d.Prob), nil
+  case Empirical:
+    if d.Weights != nil {
+      return newAliasSampler(d.Values, d.Weights), nil
+    }
+  }
+  return dist, nil
+}
+
+// The layers of a ziggurat under a decreasing density f, each with the same
+// area. Layer i has width x[i], heights f[i] to f[i+1] and a rectangle of
+// width x[i+1] that accepts without evaluating the density; the base layer's
+// width makes room for the tail past x[1]. A draw takes a 32 bit value, the
+// layer from its low 8 bits and the position from all of it, as math/rand
+// does with 128 layers, and compares the position against k[i] before
+// scaling it by w[i]. Filling a slice uses both halves of each 64 bit value,
+// which halves the calls to the source that dominate the cost of a draw.
+// Ref: Marsaglia and Tsang (2000), The Ziggurat Method for Generating Random
+// Variables.
+type ziggurat struct {
+  base  float64
+  k     [zig_layers]uint32
+  w     [zig_layers]float64
+  f     [zig_layers + 1]float64
+}
+
+var (
+  normalZiggurat = newZiggurat(zig_normal_r,
+    math.Sqrt(math.Pi / 2) * math.Erfc(zig_normal_r / math.Sqrt2), 31,
+    func(x float64) float64 { return math.Exp(-x * x / 2) },
+    func(y float64) float64 { return math.Sqrt(-2 * math.Log(y)) })
+  exponentialZiggurat = newZiggurat(zig_exponential_r,
+    math.Exp(-zig_exponential_r), 32,
+    func(x float64) float64 { return math.Exp(-x) },
+    func(y float64) float64 { return -math.Log(y) })
+)
+
+// Builds the layers from the base r, the area of the tail past it and the
+// number of bits in a position.
+func newZiggurat(r, tail float64, bits int, density, inverse func(float64) float64) ziggurat {
+  var x [zig_layers + 1]float64
+  area := (r * density(r)) + tail
+  x[0] = area / density(r)
+  x[1] = r
+  for i := 1; i < zig_layers - 1; i++ {
+    x[i + 1] = inverse(density(x[i]) + (area / x[i]))
+  }
+  z := ziggurat{ base: r }
+  scale := math.Ldexp(1, bits)
+  for i := 0; i < zig_layers; i++ {
+    z.k[i] = uint32(x[i + 1] / x[i] * scale)
+    z.w[i] = x[i] / scale
+  }
+  for i := range z.f {
+    z.f[i] = density(x[i])
+  }
+  return z
+}
+
+// Positions are signed. The rectangles are checked by the callers, and a
+// position outside them goes on to the tail or the wedge here, returning
+// false when the wedge rejects it too.
+func (z *ziggurat) normalEdge(r *rand.Rand, i uint32, x float64) (float64, bool) {
+  if i == 0 {
+    return math.Copysign(z.normalTail(r), x), true
+  }
+  if z.f[i] + ((z.f[i + 1] - z.f[i]) * randFloat64(r)) < math.Exp(-x * x / 2) {
+    return x, true
+  }
+  return 0, false
+}
+
+// Single draws call the source inline, as randUint64 is too large to inline
+// and a call would put them behind math/rand.
+func (z *ziggurat) normal(r *rand.Rand) float64 {
+  for {
+    var bits uint64
+    if r == nil {
+      bits = rand.Uint64()
+    } else {
+      bits = r.Uint64()
+    }
+    j := int32(bits)
+    i := uint32(j) & 0xff
+    x := float64(j) * z.w[i]
+    if uint32((j ^ (j >> 31)) - (j >> 31)) < z.k[i] {
+      return x
+    }
+    if result, ok := z.normalEdge(r, i, x); ok {
+      return result
+    }
+  }
+}
+
+// Marsaglia's method for the normal tail past the base.
+func (z *ziggurat) normalTail(r *rand.Rand) float64 {
+  for {
+    a := -math.Log1p(-randFloat64(r)) / z.base
+    b := -math.Log1p(-randFloat64(r))
+    if 2 * b > a * a {
+      return z.base + a
+    }
+  }
+}
+
+func (z *ziggurat) exponentialEdge(r *rand.Rand, i uint32, x float64) (float64, bool) {
+  // The exponential tail is the exponential shifted to the base.
+  if i == 0 {
+    return z.base - math.Log1p(-randFloat64(r)), true
+  }
+  if z.f[i] + ((z.f[i + 1] - z.f[i]) * randFloat64(r)) < math.Exp(-x) {
+    return x, true
+  }
+  return 0, false
+}
+
+func (z *ziggurat) exponential(r *rand.Rand) float64 {
+  for {
+    var bits uint64
+    if r == nil {
+      bits = rand.Uint64()
+    } else {
+      bits = r.Uint64()
+    }
+    j := uint32(bits)
+    i := j & 0xff
+    x := float64(j) * z.w[i]
+    if j < z.k[i] {
+      return x
+    }
+    if result, ok := z.exponentialEdge(r, i, x); ok {
+      return result
+    }
+  }
+}
+
+type normalSampler struct {
+  mu     float64
+  sigma  float64
+}
+
+func (s normalSampler) RandomWith(r *rand.Rand) float64 {
+  return s.mu + (s.sigma * normalZiggurat.normal(r))
+}
+
+func (s normalSampler) Random() float64 {
+  return s.RandomWith(nil)
+}
+
+// Fills dst two draws at a time. A draw the wedge rejects starts over with a
+// fresh value of its own.
+func (s normalSampler) fill(dst []float64, r *rand.Rand) {
+  z := &normalZiggurat
+  for n := 0; n < len(dst); n += 2 {
+    bits := randUint64(r)
+    for h := n; h < n + 2 && h < len(dst); h++ {
+      j := int32(bits)
+      bits >>= 32
+      i := uint32(j) & 0xff
+      x := float64(j) * z.w[i]
+      if uint32((j ^ (j >> 31)) - (j >> 31)) >= z.k[i] {
+        var ok bool
+        if x, ok = z.normalEdge(r, i, x); !ok {
+          x = z.normal(r)
+        }
+      }
+      dst[h] = s.mu + (s.sigma * x)
+    }
+  }
+}
+
+type exponentialSampler struct {
+  lambda  float64
+}
+
+func (s exponentialSampler) RandomWith(r *rand.Rand) float64 {
+  return s.lambda * exponentialZiggurat.exponential(r)
+}
+
+func (s exponentialSampler) Random() float64 {
+  return s.RandomWith(nil)
+}
+
+func (s exponentialSampler) fill(dst []float64, r *rand.Rand) {
+  z := &exponentialZiggurat
+  for n := 0; n < len(dst); n += 2 {
+    bits := randUint64(r)
+    for h := n; h < n + 2 && h < len(dst); h++ {
+      j := uint32(bits)
+      bits >>= 32
+      i := j & 0xff
+      x := float64(j) * z.w[i]
+      if j >= z.k[i] {
+        var ok bool
+        if x, ok = z.exponentialEdge(r, i, x); !ok {
+          x = z.exponential(r)
+        }
+      }
+      dst[h] = s.lambda * x
+    }
+  }
+}
+
+// Uses multiplication of uniforms below ptrs_cutoff and the transformed
+// rejection with squeeze above it.
+// Ref: Hörmann (1993), The Transformed Rejection Method for Generating
+// Poisson Random Variables.
+type poissonSampler struct {
+  mu          float64
+  emu         float64
+  logMu       float64
+  a           float64
+  b           float64
+  vr          float64
+  logAlpha    float64
+}
+
+func newPoissonSampler(mu float64) poissonSampler {
+  s := poissonSampler{ mu: mu, emu: math.Exp(-mu), logMu: math.Log(mu) }
+  if mu >= ptrs_cutoff {
+    s.b = 0.931 + (2.53 * math.Sqrt(mu))
+    s.a = -0.059 + (0.02483 * s.b)
+    s.vr = 0.9277 - (3.6224 / (s.b - 2))
+    s.logAlpha = math.Log(1.1239 + (1.1328 / (s.b - 3.4)))
+  }
+  return s
+}
+
+func (s poissonSampler) RandomWith(r *rand.Rand) float64 {
+  if s.mu < ptrs_cutoff {
+    k := 0.0
+    prod := randFloat64(r)
+    for prod > s.emu {
+      prod *= randFloat64(r)
+      k++
+    }
+    return k
+  }
+  for {
+    u := randFloat64(r) - 0.5
+    v := randFloat64(r)
+    us := 0.5 - math.Abs(u)
+    k := math.Floor((((2 * s.a / us) + s.b) * u) + s.mu + 0.43)
+    if us >= 0.07 && v <= s.vr {
+      return k
+    }
+    if k < 0 || (us < 0.013 && v > us) {
+      continue
+    }
+    lg, _ := math.Lgamma(k + 1)
+    if math.Log(v) + s.logAlpha - math.Log((s.a / (us * us)) + s.b) <= -s.mu + (k * s.logMu) - lg {
+      return k
+    }
+  }
+}
+
+func (s poissonSampler) Random() float64 {
+  return s.RandomWith(nil)
+}
+
+// Uses inversion of a table of the Cdf below btpe_cutoff and the triangle,
+// parallelogram and exponential tails with every squeeze above it, always for
+// p <= 1/2 by symmetry.
+// Ref: Kachitvichyanukul and Schmeiser (1988), Binomial Random Variate
+// Generation.
+// Ref: https://github.com/numpy/numpy/blob/main/numpy/random/src/distributions/distributions.c
+type binomialSampler struct {
+  n        float64
+  p        float64
+  q        float64
+  flipped  bool
+  // Inversion, with the Cdf up to ten standard deviations past the mean.
+  cdf      []float64
+  // BTPE.
+  m        float64
+  xm       float64
+  xl       float64
+  xr       float64
+  c        float64
+  lambdaL  float64
+  lambdaR  float64
+  p1       float64
+  p2       float64
+  p3       float64
+  p4       float64
+  nrq      float64
+}
+
+func newBinomialSampler(n, prob float64) binomialSampler {
+  s := binomialSampler{ n: n, p: prob, flipped: prob > 0.5 }
+  if s.flipped {
+    s.p = 1 - prob
+  }
+  s.q = 1 - s.p
+  np := n * s.p
+  if np < btpe_cutoff {
+    bound := math.Min(n, np + (10 * math.Sqrt((np * s.q) + 1)))
+    px := math.Exp(n * math.Log1p(-s.p))
+    cum := px
+    s.cdf = append(make([]float64, 0, int(bound) + 1), cum)
+    for x := 1.0; x <= bound; x++ {
+      px *= (n - x + 1) * s.p / (x * s.q)
+      cum += px
+      s.cdf = append(s.cdf, cum)
+    }
+    return s
+  }
+  fm := np + s.p
+  s.m = math.Floor(fm)
+  s.nrq = np * s.q
+  s.p1 = math.Floor((2.195 * math.Sqrt(s.nrq)) - (4.6 * s.q)) + 0.5
+  s.xm = s.m + 0.5
+  s.xl = s.xm - s.p1
+  s.xr = s.xm + s.p1
+  s.c = 0.134 + (20.5 / (15.3 + s.m))
+  a := (fm - s.xl) / (fm - (s.xl * s.p))
+  s.lambdaL = a * (1 + (a / 2))
+  a = (s.xr - fm) / (s.xr * s.q)
+  s.lambdaR = a * (1 + (a / 2))
+  s.p2 = s.p1 * (1 + (2 * s.c))
+  s.p3 = s.p2 + (s.c / s.lambdaL)
+  s.p4 = s.p3 + (s.c / s.lambdaR)
+  return s
+}
+
+func (s binomialSampler) RandomWith(r *rand.Rand) float64 {
+  var y float64
+  switch {
+  case s.n == 0 || s.p == 0:
+    y = 0
+  case s.n * s.p < btpe_cutoff:
+    y = s.inversion(r)
+  default:
+    y = s.btpe(r)
+  }
+  if s.flipped {
+    return s.n - y
+  }
+  return y
+}
+
+func (s binomialSampler) Random() float64 {
+  return s.RandomWith(nil)
+}
+
+// Draws past the end of the table, which holds all but a negligible part of
+// the mass, are redrawn.
+func (s binomialSampler) inversion(r *rand.Rand) float64 {
+  for {
+    u := randFloat64(r)
+    for x, cum := range s.cdf {
+      if u < cum {
+        return float64(x)
+      }
+    }
+  }
+}
+
+func (s binomialSampler) btpe(r *rand.Rand) float64 {
+  for {
+    u := randFloat64(r) * s.p4
+    v := randFloat64(r)
+    // The triangle in the middle accepts without a test.
+    if u <= s.p1 {
+      return math.Floor(s.xm - (s.p1 * v) + u)
+    }
+    var y float64
+    switch {
+    case u <= s.p2:
+      x := s.xl + ((u - s.p1) / s.c)
+      v = (v * s.c) + 1 - (math.Abs(s.m - x + 0.5) / s.p1)
+      if v > 1 {
+        continue
+      }
+      y = math.Floor(x)
+    case u <= s.p3:
+      y = math.Floor(s.xl + (math.Log(v) / s.lambdaL))
+      if y < 0 || v == 0 {
+        continue
+      }
+      v *= (u - s.p2) * s.lambdaL
+    default:
+      y = math.Floor(s.xr - (math.Log(v) / s.lambdaR))
+      if y > s.n || v == 0 {
+        continue
+      }
+      v *= (u - s.p3) * s.lambdaR
+    }
+    if s.accept(y, v) {
+      return y
+    }
+  }
+}
+
+// Compares v to f(y) / f(m), exactly by recursion near the mode and
+// otherwise through the squeezes and Stirling's approximation.
+func (s binomialSampler) accept(y, v float64) bool {
+  k := math.Abs(y - s.m)
+  if k <= 20 || k >= (s.nrq / 2) - 1 {
+    ratio := s.p / s.q
+    a := ratio * (s.n + 1)
+    f := 1.0
+    if s.m < y {
+      for i := s.m + 1; i <= y; i++ {
+        f *= (a / i) - ratio
+      }
+    } else {
+      for i := y + 1; i <= s.m; i++ {
+        f /= (a / i) - ratio
+      }
+    }
+    return v <= f
+  }
+  rho := (k / s.nrq) * ((((k * ((k / 3) + 0.625)) + (1.0 / 6)) / s.nrq) + 0.5)
+  t := -k * k / (2 * s.nrq)
+  logV := math.Log(v)
+  if logV < t - rho {
+    return true
+  }
+  if logV > t + rho {
+    return false
+  }
+  x1 := y + 1
+  f1 := s.m + 1
+  z := s.n + 1 - s.m
+  w := s.n - y + 1
+  bound := (s.xm * math.Log(f1 / x1)) + ((s.n - s.m + 0.5) * math.Log(z / w)) +
+    ((y - s.m) * math.Log(w * s.p / (x1 * s.q))) +
+    stirlingTail(f1) + stirlingTail(z) - stirlingTail(x1) - stirlingTail(w)
+  return logV <= bound
+}
+
+// The series 1/12x - 1/360x³ + ... of log Γ(x) less Stirling's formula,
+// nested as in BTPE but with 13860 where the paper misprints 13680.
+func stirlingTail(x float64) float64 {
+  x2 := x * x
+  return (13860 - ((462 - ((132 - ((99 - (140 / x2)) / x2)) / x2)) / x2)) / x / 166320
+}
+
+// Walker's alias method with Vose's construction: every draw picks a column
+// uniformly and then either its value or its alias.
+// Ref: Vose (1991), A Linear Algorithm for Generating Random Numbers with a
+// Given Distribution.
+type aliasSampler struct {
+  values  []float64
+  probs   []float64
+  alias   []int
+}
+
+func newAliasSampler(values, weights []float64) aliasSampler {
+  n := len(values)
+  s := aliasSampler{ values: values, probs: make([]float64, n), alias: make([]int, n) }
+  total := 0.0
+  for _, weight := range weights {
+    total += weight
+  }
+  scaled := make([]float64, n)
+  small := make([]int, 0, n)
+  large := make([]int, 0, n)
+  for i, weight := range weights {
+    scaled[i] = weight * float64(n) / total
+    if scaled[i] < 1 {
+      small = append(small, i)
+    } else {
+      large = append(large, i)
+    }
+  }
+  for len(small) > 0 && len(large) > 0 {
+    less := small[len(small) - 1]
+    small = small[:len(small) - 1]
+    more := large[len(large) - 1]
+    s.probs[less] = scaled[less]
+    s.alias[less] = more
+    scaled[more] = (scaled[more] + scaled[less]) - 1
+    if scaled[more] < 1 {
+      large = large[:len(large) - 1]
+      small = append(small, more)
+    }
+  }
+  // Whatever is left is one up to rounding.
+  for _, i := range append(small, large...) {
+    s.probs[i] = 1
+    s.alias[i] = i
+  }
+  return s
+}
+
+// One uniform picks the column with its whole part and the value or alias
+// with its fraction.
+func (s aliasSampler) RandomWith(r *rand.Rand) float64 {
+  u := randFloat64(r) * float64(len(s.values))
+  i := int(u)
+  if i == len(s.values) {
+    i--
+  }
+  if u - float64(i) < s.probs[i] {
+    return s.values[i]
+  }
+  return s.values[s.alias[i]]
+}
+
+func (s aliasSampler) Random() float64 {
+  return s.RandomWith(nil)
+}
@@ -0,0 +1,201 @@
+package prob
+
+import (
+  "errors"
+  "fmt"
+  "math"
+  "math/rand"
+  "testing"
+)
+
+const samplerSamples = 200000
+
+// Edges on the whole numbers from lo to hi.
+func integerEdges(lo, hi, step float64) []float64 {
+  edges := []float64{}
+  for x := math.Floor(lo); x <= hi; x += step {
+    edges = append(edges, x)
+  }
+  return edges
+}
+
+// Edges at mu + sigma·z, including both sides of the ziggurat's base.
+func normalEdges(mu, sigma float64) []float64 {
+  edges := []float64{ mu - (4 * sigma), mu - (zig_normal_r * sigma) }
+  for z := -3.5; z <= 3.5; z += 0.25 {
+    edges = append(edges, mu + (z * sigma))
+  }
+  return append(edges, mu + (zig_normal_r * sigma), mu + (4 * sigma))
+}
+
+// Tests a prepared sampler with a seeded chi-squared goodness-of-fit test.
+func testSampler(dist Distribution, edges []float64) error {
+  sampler, err := NewSampler(dist)
+  if err != nil {
+    return err
+  }
+  r := rand.New(rand.NewSource(1))
+  samples := make([]float64, samplerSamples)
+  for i := range samples {
+    samples[i] = sampler.RandomWith(r)
+  }
+  if err := checkSamplerFit(dist, samples, edges); err != nil {
+    return err
+  }
+  // SampleInto fills with the ziggurats two draws at a time.
+  if _, ok := sampler.(interface{ fill([]float64, *rand.Rand) }); !ok {
+    return nil
+  }
+  if err := SampleInto(dist, samples, r); err != nil {
+    return err
+  }
+  return checkSamplerFit(dist, samples, edges)
+}
+
+func checkSamplerFit(dist Distribution, samples, edges []float64) error {
+  result, err := ChiSquaredDistributionTest(samples, dist, edges, 0)
+  if err != nil {
+    return err
+  }
+  if result.PValue < 0.001 {
+    return fmt.Errorf("\n%#v:\n  Expected: p-value > %f\n  Got: %f\n", dist, 0.001, result.PValue)
+  }
+  return nil
+}
+
+func Test_Sampler(t *testing.T) {
+  exponential := []float64{}
+  for x := 0.25; x < 7; x += 0.25 {
+    exponential = append(exponential, 2 * x)
+  }
+  exponential = append(exponential, 2 * zig_exponential_r, 2 * 9)
+  tests := []struct {
+    dist   Distribution
+    edges  []float64
+  }{
+    { Normal{ 2.0, 3.0 }, normalEdges(2.0, 3.0) },
+    { Exponential{ 2.0 }, exponential },
+    { Poisson{ 4.5 }, integerEdges(0, 12, 1) },
+    { Poisson{ 50 }, integerEdges(25, 80, 1) },
+    { Poisson{ 1e4 }, integerEdges(1e4 - 400, 1e4 + 400, 25) },
+    { Binomial{ 10, 0.5 }, integerEdges(1, 9, 1) },
+    { Binomial{ 100, 0.9 }, integerEdges(80, 99, 1) },
+    { Binomial{ 200, 0.3 }, integerEdges(35, 85, 1) },
+    { Binomial{ 1e6, 0.7 }, integerEdges(7e5 - 2000, 7e5 + 2000, 100) },
+    { Empirical{ Values: []float64{ 1, 2, 3, 4, 5 }, Weights: []float64{ 0.1, 0, 2, 0.5, 1 } }, []float64{ 1, 3, 4 } },
+  }
+  for _, test := range tests {
+    if err := testSampler(test.dist, test.edges); err != nil {
+      t.Fatal(err)
+    }
+  }
+
+  // Other distributions sample through their own RandomWith.
+  if sampler, err := NewSampler(Gamma{ 2.0, 1.0 }); err != nil || sampler != Sampler(Gamma{ 2.0, 1.0 }) {
+    t.Fatalf("\n  Expected: %v\n  Got: %v, %v\n", Gamma{ 2.0, 1.0 }, sampler, err)
+  }
+  if _, err := NewSampler(Binomial{ 10, 2 }); !errors.Is(err, ErrInvalidParams) {
+    t.Fatalf("\n  Expected: %v\n  Got: %v\n", ErrInvalidParams, err)
+  }
+}
+
+// BTPE decides between its squeezes for 20 < |y - m| < npq/2 with
+// Stirling's approximation, which must agree with the exact ratio
+// f(y)/f(m) on either side of it.
+func Test_Sampler_BTPE(t *testing.T) {
+  for _, dist := range []Binomial{ Binomial{ 200, 0.5 }, Binomial{ 300, 0.5 }, Binomial{ 300, 0.4 }, Binomial{ 1000, 0.5 } } {
+    s := newBinomialSampler(dist.Trials, dist.Prob)
+    logF := func(y float64) float64 {
+      a, _ := math.Lgamma(y + 1)
+      b, _ := math.Lgamma(s.n - y + 1)
+      return -a - b + (y * math.Log(s.p / s.q))
+    }
+    for k := 21.0; k < (s.nrq / 2) - 1; k++ {
+      for _, y := range []float64{ s.m - k, s.m + k } {
+        ratio := logF(y) - logF(s.m)
+        if !s.accept(y, math.Exp(ratio - 1e-9)) || s.accept(y, math.Exp(ratio + 1e-9)) {
+          t.Fatalf("\n%#v at %v:\n  Expected: to accept below f(y)/f(m) = %v and reject above\n", dist, y, math.Exp(ratio))
+        }
+      }
+    }
+  }
+
+  // Fine bins over the Stirling region itself.
+  dist := Binomial{ 300, 0.5 }
+  edges := append(integerEdges(110, 130, 1), integerEdges(171, 191, 1)...)
+  if err := testSampler(dist, edges); err != nil {
+    t.Fatal(err)
+  }
+}
+
+func runSamplerBenchmark(b *testing.B, dist Distribution) {
+  sampler, _ := NewSampler(dist)
+  b.ResetTimer()
+  for n := 0; n < b.N; n++ {
+    sampler.Random()
+  }
+}
+
+// Fills a slice in blocks, timing each draw.
+func runSampleIntoBenchmark(b *testing.B, dist Distribution) {
+  dst := make([]float64, 1024)
+  for n := 0; n < b.N; n += len(dst) {
+    if b.N - n < len(dst) {
+      dst = dst[:b.N - n]
+    }
+    SampleInto(dist, dst, nil)
+  }
+}
+
+// Against Benchmark_Normal and Benchmark_Exponential, which use math/rand.
+// Single draws cost about the same, as both spend most of their time in the
+// source; filling takes two draws from each of its values.
+func Benchmark_Normal_Sampler(b *testing.B) {
+  runSamplerBenchmark(b, Normal{10.0, 4.0})
+}
+
+func Benchmark_Normal_SampleInto(b *testing.B) {
+  runSampleIntoBenchmark(b, Normal{10.0, 4.0})
+}
+
+func Benchmark_Exponential_Sampler(b *testing.B) {
+  runSamplerBenchmark(b, Exponential{4.0})
+}
+
+func Benchmark_Exponential_SampleInto(b *testing.B) {
+  runSampleIntoBenchmark(b, Exponential{4.0})
+}
+
+func Benchmark_Poisson_Sampler(b *testing.B) {
+  runSamplerBenchmark(b, Poisson{11.0})
+}
+
+func Benchmark_Poisson_Large(b *testing.B) {
+  runBenchmark(b, Poisson{1e4})
+}
+
+func Benchmark_Poisson_Large_Sampler(b *testing.B) {
+  runSamplerBenchmark(b, Poisson{1e4})
+}
+
+func Benchmark_Binomial_Sampler(b *testing.B) {
+  runSamplerBenchmark(b, Binomial{10.0, 0.5})
+}
+
+func Benchmark_Binomial_Large(b *testing.B) {
+  runBenchmark(b, Binomial{1e4, 0.3})
+}
+
+func Benchmark_Binomial_Large_Sampler(b *testing.B) {
+  runSamplerBenchmark(b, Binomial{1e4, 0.3})
+}
+
+func Benchmark_Empirical_Weighted(b *testing.B) {
+  dist, _ := NewWeightedEmpirical([]float64{ 1.0, 2.0, 3.0, 4.0, 10.0 }, []float64{ 1.0, 0.5, 2.0, 3.0, 0.25 })
+  runBenchmark(b, dist)
+}
+
+func Benchmark_Empirical_Weighted_Sampler(b *testing.B) {
+  dist, _ := NewWeightedEmpirical([]float64{ 1.0, 2.0, 3.0, 4.0, 10.0 }, []float64{ 1.0, 0.5, 2.0, 3.0, 0.25 })
+  runSamplerBenchmark(b, dist)
+}
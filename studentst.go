@@ -2,6 +2,7 @@ package prob
 
 import (
   "math"
+  "math/rand"
 )
 
 //The Student's t-Distribution is a continuous probability distribution
@@ -21,8 +22,8 @@ func NewStudentsT(degrees float64) (StudentsT, error) {
 }
 
 func (dist StudentsT) Validate() error {
-  if dist.Degrees <= 0 {
-    return InvalidParamsError{ "Degrees must be greater than zero." }
+  if err := checkPositive("StudentsT", "Degrees", dist.Degrees); err != nil {
+    return err
   }
   return nil
 }
@@ -75,60 +76,141 @@ func (dist StudentsT) RelStdDev() float64 {
   return math.NaN()
 }
 
+// Computed in log space with Loader's Stirling and deviance terms, so that
+// neither the gamma ratio nor the power underflows.
+// Ref: https://github.com/wch/r-source/blob/trunk/src/nmath/dt.c
 func (dist StudentsT) Pdf(x float64) float64 {
-  lg1, _ := math.Lgamma(dist.Degrees / 2)
-  lg2, _ := math.Lgamma((dist.Degrees + 1) / 2)
-  result := math.Exp(lg2 - lg1) * math.Pow(1 + (x * x / dist.Degrees), -(dist.Degrees + 1) / 2) / math.Sqrt(math.Pi * dist.Degrees)
+  if math.IsInf(x, 0) {
+    return 0.0
+  }
+  expo, root := dist.density(x, dist.stirlingTerm())
+  result := math.Exp(expo) * root / math.Sqrt(2 * math.Pi)
   return result
 }
 
-// Ref: https://github.com/chbrown/nlp/blob/master/src/main/java/cc/mallet/util/StatFunctions.java
-func (dist StudentsT) Cdf(x float64) float64 {
-  g1 := 1.0 / math.Pi
-  idf := dist.Degrees
-  a := x / math.Sqrt(idf)
-  b := idf / (idf + x * x)
-  im2 := dist.Degrees - 2.0
-  ioe := math.Mod(idf, 2.0)
-  s := 1.0
-  c := 1.0
-  idf = 1.0
-  ks := 2.0 + ioe
-  fk := ks
-  if im2 >= 2.0 {
-    for k := ks; k <= im2; k += 2.0 {
-      c = c * b * (fk - 1.0) / fk
-      s += c
-      if s != idf {
-        idf = s
-        fk += 2.0
-      }
+func (dist StudentsT) PdfSlice(xs, out []float64) {
+  t := dist.stirlingTerm()
+  denom := math.Sqrt(2 * math.Pi)
+  for i, x := range xs {
+    if math.IsInf(x, 0) {
+      out[i] = 0.0
+      continue
     }
+    expo, root := dist.density(x, t)
+    out[i] = math.Exp(expo) * root / denom
+  }
+}
+
+func (dist StudentsT) LogPdfSlice(xs, out []float64) {
+  t := dist.stirlingTerm()
+  logDenom := math.Log(2 * math.Pi) / 2
+  for i, x := range xs {
+    if math.IsInf(x, 0) {
+      out[i] = math.Inf(-1)
+      continue
+    }
+    expo, root := dist.density(x, t)
+    out[i] = expo + math.Log(root) - logDenom
+  }
+}
+
+// The part of the log density that does not depend on x.
+func (dist StudentsT) stirlingTerm() float64 {
+  v := dist.Degrees
+  result := -devianceTerm(v / 2, (v + 1) / 2) + stirlingError((v + 1) / 2) - stirlingError(v / 2)
+  return result
+}
+
+// Splits the density at x into e^expo · root / √(2π), given the Stirling term.
+func (dist StudentsT) density(x, t float64) (float64, float64) {
+  v := dist.Degrees
+  x2n := x * x / v
+  var u, root float64
+  switch {
+  case x2n > 1 / 2.2204460492503131e-16:
+    u = v * (math.Log(math.Abs(x)) - (math.Log(v) / 2))
+    root = math.Sqrt(v) / math.Abs(x)
+  case x2n > 0.2:
+    u = v * math.Log(1 + x2n) / 2
+    root = 1 / math.Sqrt(1 + x2n)
+  default:
+    u = -devianceTerm(v / 2, (v + (x * x)) / 2) + (x * x / 2)
+    root = 1 / math.Sqrt(1 + x2n)
+  }
+  return t - u, root
+}
+
+// Uses P(|T| > |t|) = I_x(ν/2, 1/2) with x = ν / (ν + t²), passing 1 - x
+// separately to keep the centre precise, and the tail's leading term once t²
+// is too large for x. Very large ν uses the normal with a first order
+// correction.
+// Ref: https://github.com/wch/r-source/blob/trunk/src/nmath/pt.c
+func (dist StudentsT) Cdf(x float64) float64 {
+  v := dist.Degrees
+  if math.IsNaN(x) {
+    return math.NaN()
+  }
+  if math.IsInf(x, 0) {
+    return math.Max(0.0, math.Copysign(1.0, x))
   }
-  if ioe != 1 {
-    result := 0.5 + (0.5  * a * math.Sqrt(b) * s)
+  if v > 4e5 {
+    val := 1 / (4 * v)
+    result := Normal{ 0, 1 }.Cdf(x * (1 - val) / math.Sqrt(1 + (x * x * 2 * val)))
     return result
   }
-  if dist.Degrees == 1 {
-    s = 0
+  var tail float64
+  nx := 1 + (x / v * x)
+  if nx > 1e100 {
+    lval := (-0.5 * v * ((2 * math.Log(math.Abs(x))) - math.Log(v))) - LogBeta(v / 2, 0.5) - math.Log(v / 2)
+    tail = math.Exp(lval)
+  } else {
+    tail = regBetaInc(v / 2, 0.5, 1 / nx, (x / v * x) / nx)
   }
-  result := 0.5 + (((a * b * s) + math.Atan(a)) * g1)
-  return result
+  if x > 0 {
+    return 1 - (tail / 2)
+  }
+  return tail / 2
+}
+
+func (dist StudentsT) Quantile(p float64) float64 {
+  if p == 0 {
+    return math.Inf(-1)
+  }
+  if p == 1 {
+    return math.Inf(1)
+  }
+  if p < 0 || p > 1 || math.IsNaN(p) {
+    return math.NaN()
+  }
+  // Invert whichever of x = ν / (ν + t²) or 1 - x is further from one.
+  tail := math.Min(p, 1 - p)
+  var t float64
+  if tail < 0.25 {
+    x := RegBetaIncInv(dist.Degrees / 2, 0.5, 2 * tail)
+    t = math.Sqrt(dist.Degrees * (1 - x) / x)
+  } else {
+    y := RegBetaIncInv(0.5, dist.Degrees / 2, 1 - (2 * tail))
+    t = math.Sqrt(dist.Degrees * y / (1 - y))
+  }
+  if p < 0.5 {
+    return -t
+  }
+  return t
 }
 
 // Ref: https://github.com/ampl/gsl/blob/master/randist/tdist.c
-func (dist StudentsT) Random() float64 {
+func (dist StudentsT) RandomWith(r *rand.Rand) float64 {
   if (dist.Degrees <= 2) {
-    y1 := Normal{ Mu: 0, Sigma: 1 }.Random()
-    y2 := ChiSquared{ Degrees: dist.Degrees }.Random()
+    y1 := Normal{ Mu: 0, Sigma: 1 }.RandomWith(r)
+    y2 := ChiSquared{ Degrees: dist.Degrees }.RandomWith(r)
     result := y1 / math.Sqrt(y2 / dist.Degrees)
     return result
   } else {
     var y1, y2, z float64
     ok := true
     for ok {
-      y1 = Normal{ Mu: 0, Sigma: 1 }.Random()
-      y2 = Exponential{ Lambda: 1 / ((dist.Degrees / 2) - 1) }.Random()
+      y1 = Normal{ Mu: 0, Sigma: 1 }.RandomWith(r)
+      y2 = Exponential{ Lambda: 1 / ((dist.Degrees / 2) - 1) }.RandomWith(r)
       z = y1 * y2 / (dist.Degrees - 2)
       ok = 1 - z < 0 || math.Exp(-y2 - z) > 1 - z
     }
@@ -136,3 +218,7 @@ func (dist StudentsT) Random() float64 {
     return result
   }
 }
+
+func (dist StudentsT) Random() float64 {
+  return dist.RandomWith(nil)
+}
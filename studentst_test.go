@@ -6,6 +6,8 @@ import (
 )
 
 // Test at http://keisan.casio.com/exec/system/1180573203
+// Non-integer degrees of freedom are tested by quadrature of the density and
+// the incomplete beta series.
 func Test_StudentsT(t *testing.T) {
   examples := []distributionTest{
     distributionTest{
@@ -52,10 +54,78 @@ func Test_StudentsT(t *testing.T) {
     t.Fatal(err)
   }
 
+  examples = []distributionTest{
+    distributionTest{
+      dist:       StudentsT{13.7},
+      mean:       0.0,
+      variance:   13.7 / 11.7,
+      stdDev:     math.Sqrt(13.7 / 11.7),
+      relStdDev:  math.NaN(),
+      skewness:   0.0,
+      kurtosis:   3 * 11.7 / 9.7,
+      pdf: []inOut{
+        inOut{ in: 2.1,   out: 0.05037180760737058 },
+        inOut{ in: -2.1,  out: 0.05037180760737058 },
+      },
+      cdf: []inOut{
+        inOut{ in: 2.1,   out: 0.9726231115732333 },
+        inOut{ in: -2.1,  out: 0.02737688842676666 },
+        inOut{ in: 1e-10, out: 0.5000000000391734 },
+      },
+    },
+    distributionTest{
+      dist:       StudentsT{0.5},
+      mean:       math.NaN(),
+      variance:   math.NaN(),
+      stdDev:     math.NaN(),
+      relStdDev:  math.NaN(),
+      skewness:   math.NaN(),
+      kurtosis:   math.NaN(),
+      pdf: []inOut{
+        inOut{ in: -3.0,  out: 0.029633133748884065 },
+      },
+      cdf: []inOut{
+        inOut{ in: -3.0,  out: 0.18365407799297176 },
+      },
+    },
+  }
   if err := testValues(examples); err != nil {
     t.Fatal(err)
   }
 
+  // Extreme tails keep their relative precision.
+  tails := []struct{ degrees, x, pdf, cdf float64 }{
+    { 1.3,  -1e6, 7.145019524437597e-15,  5.496168864956987e-09 },
+    { 50.5, -12,  3.302428125507787e-16,  1.052990074987952e-16 },
+    { 1,    -1e200, 0,                     3.183098861837907e-201 },
+  }
+  for _, tail := range tails {
+    dist := StudentsT{tail.degrees}
+    if result := dist.Cdf(tail.x); !floatsNanoEqual(result / tail.cdf, 1) {
+      t.Fatalf("\nCdf of %v:\n  Expected: %v\n  Got: %v\n", tail.x, tail.cdf, result)
+    }
+    if result := dist.Pdf(tail.x); tail.pdf > 0 && !floatsNanoEqual(result / tail.pdf, 1) {
+      t.Fatalf("\nPdf of %v:\n  Expected: %v\n  Got: %v\n", tail.x, tail.pdf, result)
+    }
+  }
+
+  // Very large degrees of freedom approach Φ(x) - φ(x)(x³ + x) / 4ν.
+  large := StudentsT{1e6}
+  if result := large.Cdf(-2); !floatsEqual(result, 0.0227502669255955, 1e-11) {
+    t.Fatalf("\nCdf:\n  Expected: %v\n  Got: %v\n", 0.0227502669255955, result)
+  }
+
+  quantiles := []inOut{
+    inOut{ in: 0.975,  out: 2.262157162798225 },
+    inOut{ in: 0.5,    out: 0.0 },
+  }
+  for _, quantile := range quantiles {
+    out := StudentsT{9.0}.Quantile(quantile.in)
+    if !floatsNanoEqual(out, quantile.out) {
+      t.Fatalf("\nQuantile of %f:\n  Expected: %f\n  Got: %f\n", quantile.in, quantile.out, out)
+    }
+  }
+
   // Using high degrees of freedom to keep variance low.
   // A custom test would be better, but there is no closed form MLE that I am aware of.
   sample := StudentsT{15.0}
@@ -68,3 +138,11 @@ func Benchmark_StudentsT(b *testing.B) {
   dist := StudentsT{15.0}
   runBenchmark(b, dist)
 }
+
+func Benchmark_StudentsT_Pdf(b *testing.B) {
+  runPdfBenchmark(b, StudentsT{ 5.5 }, -10, 10)
+}
+
+func Benchmark_StudentsT_PdfSlice(b *testing.B) {
+  runPdfSliceBenchmark(b, StudentsT{ 5.5 }, -10, 10)
+}
@@ -0,0 +1,306 @@
+package prob
+
+import (
+  "encoding/json"
+  "fmt"
+  "math"
+  "sort"
+)
+
+const (
+  tdigest_default_compression = 100
+  tdigest_buffer_factor = 5
+)
+
+type centroid struct {
+  mean    float64
+  weight  float64
+}
+
+// TDigest is a mergeable sketch of a stream of values for estimating
+// quantiles and the Cdf in bounded memory. The compression parameter δ caps
+// the number of centroids kept at roughly δ; larger values are more
+// accurate, especially in the tails. The zero value is ready to use with a
+// compression of 100.
+//
+// Copies of a TDigest share the values buffered since the last merge, so
+// only one of them may go on adding values. Merging a digest into an empty
+// one with the same Compression makes an independent copy.
+//
+// See: https://arxiv.org/abs/1902.04023
+type TDigest struct {
+  Compression   float64
+  centroids     []centroid
+  buffer        []centroid
+  count         float64
+  min           float64
+  max           float64
+}
+
+func NewTDigest(compression float64) (TDigest, error) {
+  digest := TDigest{ Compression: compression }
+  if err := digest.Validate(); err != nil {
+    return digest, err
+  }
+  return digest, nil
+}
+
+func (d TDigest) Validate() error {
+  if err := checkNonNegative("TDigest", "Compression", d.Compression); err != nil {
+    return err
+  }
+  return nil
+}
+
+func (d TDigest) compression() float64 {
+  if d.Compression <= 0 {
+    return tdigest_default_compression
+  }
+  return d.Compression
+}
+
+// The k1 scale function and its inverse, which keep centroids small near
+// the tails.
+func (d TDigest) scale(q float64) float64 {
+  return d.compression() * math.Asin((2 * q) - 1) / (2 * math.Pi)
+}
+
+func (d TDigest) scaleInv(k float64) float64 {
+  if k >= d.compression() / 4 {
+    return 1.0
+  }
+  return (math.Sin(2 * math.Pi * k / d.compression()) + 1) / 2
+}
+
+// Adds a single value with weight one.
+func (d *TDigest) Add(x float64) {
+  d.AddWeighted(x, 1.0)
+}
+
+// Adds a value with the given weight. NaN values and non-positive weights are
+// ignored.
+func (d *TDigest) AddWeighted(x, w float64) {
+  if math.IsNaN(x) || !(w > 0) {
+    return
+  }
+  if d.count == 0 {
+    d.min, d.max = x, x
+  }
+  d.min = math.Min(d.min, x)
+  d.max = math.Max(d.max, x)
+  d.count += w
+  d.buffer = append(d.buffer, centroid{ x, w })
+  if float64(len(d.buffer)) >= tdigest_buffer_factor * d.compression() {
+    d.flush()
+  }
+}
+
+// Combines another digest into this one.
+func (d *TDigest) Merge(other TDigest) {
+  if other.count == 0 {
+    return
+  }
+  if d.count == 0 {
+    d.min, d.max = other.min, other.max
+  }
+  d.min = math.Min(d.min, other.min)
+  d.max = math.Max(d.max, other.max)
+  d.count += other.count
+  d.buffer = append(d.buffer, other.centroids...)
+  d.buffer = append(d.buffer, other.buffer...)
+  d.flush()
+}
+
+func (d *TDigest) flush() {
+  d.centroids = d.compressed()
+  d.buffer = d.buffer[:0]
+}
+
+// Merges the buffered values into the centroids without modifying the digest.
+func (d TDigest) compressed() []centroid {
+  if len(d.buffer) == 0 {
+    return d.centroids
+  }
+  all := make([]centroid, 0, len(d.centroids) + len(d.buffer))
+  all = append(all, d.centroids...)
+  all = append(all, d.buffer...)
+  sort.Slice(all, func(i, j int) bool { return all[i].mean < all[j].mean })
+  result := []centroid{ all[0] }
+  before := 0.0
+  limit := d.count * d.scaleInv(d.scale(0) + 1)
+  for _, c := range all[1:] {
+    current := &result[len(result)-1]
+    if before + current.weight + c.weight <= limit {
+      current.weight += c.weight
+      current.mean += (c.mean - current.mean) * c.weight / current.weight
+      continue
+    }
+    before += current.weight
+    limit = d.count * d.scaleInv(d.scale(before / d.count) + 1)
+    result = append(result, c)
+  }
+  return result
+}
+
+// The total weight of the values added.
+func (d TDigest) Count() float64 {
+  return d.count
+}
+
+func (d TDigest) Min() float64 {
+  if d.count == 0 {
+    return math.NaN()
+  }
+  return d.min
+}
+
+func (d TDigest) Max() float64 {
+  if d.count == 0 {
+    return math.NaN()
+  }
+  return d.max
+}
+
+// Interpolates between centroid centers, anchored at the observed min and max.
+func (d TDigest) Quantile(p float64) float64 {
+  if d.count == 0 || p < 0 || p > 1 || math.IsNaN(p) {
+    return math.NaN()
+  }
+  centroids := d.compressed()
+  if p == 0 || (len(centroids) == 1 && d.min == d.max) {
+    return d.min
+  }
+  if p == 1 {
+    return d.max
+  }
+  target := p * d.count
+  lastX, lastW := d.min, 0.0
+  cum := 0.0
+  for _, c := range centroids {
+    center := cum + (c.weight / 2)
+    if target < center {
+      return interpolate(lastW, lastX, center, c.mean, target)
+    }
+    lastX, lastW = c.mean, center
+    cum += c.weight
+  }
+  return interpolate(lastW, lastX, d.count, d.max, target)
+}
+
+// The inverse of Quantile.
+func (d TDigest) Cdf(x float64) float64 {
+  if d.count == 0 || math.IsNaN(x) {
+    return math.NaN()
+  }
+  if x < d.min {
+    return 0.0
+  }
+  if x >= d.max {
+    return 1.0
+  }
+  centroids := d.compressed()
+  lastX, lastW := d.min, 0.0
+  cum := 0.0
+  for _, c := range centroids {
+    center := cum + (c.weight / 2)
+    if x < c.mean {
+      return interpolate(lastX, lastW, c.mean, center, x) / d.count
+    }
+    lastX, lastW = c.mean, center
+    cum += c.weight
+  }
+  return interpolate(lastX, lastW, d.max, d.count, x) / d.count
+}
+
+// Linear interpolation of y at x between (x0, y0) and (x1, y1).
+func interpolate(x0, y0, x1, y1, x float64) float64 {
+  if x1 == x0 {
+    return y1
+  }
+  result := y0 + ((x - x0) * (y1 - y0) / (x1 - x0))
+  return result
+}
+
+// The largest absolute difference between the digest's Cdf and the
+// distribution's, checked at the observed min and max and either side of
+// every centroid, i.e. an estimate of the Kolmogorov–Smirnov statistic.
+//
+// See: https://en.wikipedia.org/wiki/Kolmogorov%E2%80%93Smirnov_test
+func (d TDigest) Distance(dist Distribution) float64 {
+  if d.count == 0 {
+    return math.NaN()
+  }
+  result := 0.0
+  check := func(x float64) {
+    diff := math.Abs(d.Cdf(x) - dist.Cdf(x))
+    if diff > result {
+      result = diff
+    }
+  }
+  check(d.min)
+  check(math.Nextafter(d.min, math.Inf(-1)))
+  check(d.max)
+  for _, c := range d.compressed() {
+    check(c.mean)
+    check(math.Nextafter(c.mean, math.Inf(-1)))
+  }
+  return result
+}
+
+type tdigestJSON struct {
+  Compression   float64     `json:"compression"`
+  Count         float64     `json:"count"`
+  Min           float64     `json:"min"`
+  Max           float64     `json:"max"`
+  Means         []float64   `json:"means"`
+  Weights       []float64   `json:"weights"`
+}
+
+func (d TDigest) MarshalJSON() ([]byte, error) {
+  centroids := d.compressed()
+  out := tdigestJSON{
+    Compression:  d.Compression,
+    Count:        d.count,
+    Min:          d.min,
+    Max:          d.max,
+    Means:        make([]float64, len(centroids)),
+    Weights:      make([]float64, len(centroids)),
+  }
+  for i, c := range centroids {
+    out.Means[i], out.Weights[i] = c.mean, c.weight
+  }
+  return json.Marshal(out)
+}
+
+func (d *TDigest) UnmarshalJSON(data []byte) error {
+  var in tdigestJSON
+  if err := json.Unmarshal(data, &in); err != nil {
+    return err
+  }
+  if len(in.Means) != len(in.Weights) {
+    return InvalidParamsError{ Dist: "TDigest", Param: "Weights", Value: float64(len(in.Weights)), Constraint: "have the same length as Means" }
+  }
+  digest := TDigest{ Compression: in.Compression, count: in.Count, min: in.Min, max: in.Max }
+  if err := digest.Validate(); err != nil {
+    return err
+  }
+  digest.centroids = make([]centroid, len(in.Means))
+  total := 0.0
+  for i := range in.Means {
+    if err := checkFinite("TDigest", "Means", in.Means[i]); err != nil {
+      return err
+    }
+    if err := checkPositive("TDigest", "Weights", in.Weights[i]); err != nil {
+      return err
+    }
+    digest.centroids[i] = centroid{ in.Means[i], in.Weights[i] }
+    total += in.Weights[i]
+  }
+  // The count is summed in the order values were added, so it may differ
+  // from the weights' sum by rounding.
+  if !(math.Abs(in.Count - total) <= 1e-9 * total) {
+    return InvalidParamsError{ Dist: "TDigest", Param: "Count", Value: in.Count, Constraint: fmt.Sprintf("equal the sum of Weights, %v", total) }
+  }
+  *d = digest
+  return nil
+}
@@ -0,0 +1,138 @@
+package prob
+
+import (
+  "encoding/json"
+  "errors"
+  "testing"
+)
+
+func Test_TDigest(t *testing.T) {
+  digest, err := NewTDigest(100)
+  if err != nil {
+    t.Fatal(err)
+  }
+  dist := Normal{ 0.0, 1.0 }
+  samples, _ := Sample(dist, 200000)
+  for _, x := range samples {
+    digest.Add(x)
+  }
+  quantiles := []inOut{
+    inOut{ in: 0.005,  out: -2.575829303548900384159 },
+    inOut{ in: 0.01,   out: -2.326347874040841100886 },
+    inOut{ in: 0.25,   out: -0.6744897501960817432022 },
+    inOut{ in: 0.5,    out: 0.0 },
+    inOut{ in: 0.99,   out: 2.326347874040841100886 },
+  }
+  for _, example := range quantiles {
+    out := digest.Quantile(example.in)
+    if !floatsDeciEqual(out, example.out) {
+      t.Fatalf("\nQuantile of %f:\n  Expected: %f\n  Got: %f\n", example.in, example.out, out)
+    }
+  }
+  for _, x := range []float64{ -2.0, -0.5, 0.0, 1.0, 3.0 } {
+    if out := digest.Cdf(x); !floatsEqual(out, dist.Cdf(x), 0.005) {
+      t.Fatalf("\nCdf of %f:\n  Expected: %f\n  Got: %f\n", x, dist.Cdf(x), out)
+    }
+  }
+  if distance := digest.Distance(dist); distance > 0.01 {
+    t.Fatalf("\nDistance to fitted model too large: %f\n", distance)
+  }
+  if distance := digest.Distance(Normal{ 0.5, 1.0 }); distance < 0.1 {
+    t.Fatalf("\nDistance to shifted model too small: %f\n", distance)
+  }
+  if digest.Count() != 200000 || len(digest.compressed()) > 200 {
+    t.Fatalf("\nCount: %f\nCentroids: %d\n", digest.Count(), len(digest.compressed()))
+  }
+}
+
+func Test_TDigest_Merge(t *testing.T) {
+  dist := Exponential{ 2.0 }
+  parts := make([]TDigest, 4)
+  samples, _ := Sample(dist, 100000)
+  for i, x := range samples {
+    parts[i % len(parts)].Add(x)
+  }
+  var merged TDigest
+  for _, part := range parts {
+    merged.Merge(part)
+  }
+  if merged.Count() != 100000 {
+    t.Fatalf("\nCount:\n  Expected: %d\n  Got: %f\n", 100000, merged.Count())
+  }
+  if distance := merged.Distance(dist); distance > 0.01 {
+    t.Fatalf("\nDistance after merge too large: %f\n", distance)
+  }
+}
+
+func Test_TDigest_JSON(t *testing.T) {
+  var digest TDigest
+  samples, _ := Sample(Gamma{ 2.0, 1.0 }, 10000)
+  for _, x := range samples {
+    digest.Add(x)
+  }
+  data, err := json.Marshal(digest)
+  if err != nil {
+    t.Fatal(err)
+  }
+  var restored TDigest
+  if err := json.Unmarshal(data, &restored); err != nil {
+    t.Fatal(err)
+  }
+  for _, p := range []float64{ 0.0, 0.05, 0.5, 0.95, 1.0 } {
+    if digest.Quantile(p) != restored.Quantile(p) {
+      t.Fatalf("\nQuantile of %f:\n  Expected: %f\n  Got: %f\n", p, digest.Quantile(p), restored.Quantile(p))
+    }
+  }
+  if _, err := NewTDigest(-1); err == nil {
+    t.Fatal("\nExpected an error for negative compression.")
+  }
+
+  invalid := []struct {
+    data   string
+    param  string
+  }{
+    { `{"count":3,"min":1,"max":2,"means":[1,2],"weights":[1,-1]}`, "Weights" },
+    { `{"count":1,"min":1,"max":2,"means":[1,2],"weights":[1,0]}`, "Weights" },
+    { `{"count":3,"min":1,"max":2,"means":[1,2],"weights":[2,1.5]}`, "Count" },
+  }
+  for _, example := range invalid {
+    var params InvalidParamsError
+    err := json.Unmarshal([]byte(example.data), &restored)
+    if !errors.As(err, &params) || params.Param != example.param {
+      t.Fatalf("\n%s:\n  Expected: an InvalidParamsError for %s\n  Got: %v\n", example.data, example.param, err)
+    }
+  }
+  empty := `{"count":0,"min":0,"max":0,"means":[],"weights":[]}`
+  if err := json.Unmarshal([]byte(empty), &restored); err != nil || restored.Count() != 0 {
+    t.Fatalf("\n%s:\n  Expected: an empty digest\n  Got: %v\n", empty, err)
+  }
+}
+
+// Copies share buffered values, so a copy is made by merging.
+func Test_TDigest_Copy(t *testing.T) {
+  digest := TDigest{ Compression: 50 }
+  for x := 1.0; x <= 10; x++ {
+    digest.Add(x)
+  }
+  copied := TDigest{ Compression: digest.Compression }
+  copied.Merge(digest)
+  digest.Add(100)
+  copied.Add(-100)
+  if digest.Min() != 1 || digest.Max() != 100 || digest.Quantile(1) != 100 || digest.Count() != 11 {
+    t.Fatalf("\nOriginal:\n  Expected: 11 values from 1 to 100\n  Got: %v values from %v to %v\n", digest.Count(), digest.Min(), digest.Max())
+  }
+  if copied.Min() != -100 || copied.Max() != 10 || copied.Quantile(0) != -100 || copied.Count() != 11 {
+    t.Fatalf("\nCopy:\n  Expected: 11 values from -100 to 10\n  Got: %v values from %v to %v\n", copied.Count(), copied.Min(), copied.Max())
+  }
+  if p := digest.Cdf(50); !(p > 0.8 && p < 1) {
+    t.Fatalf("\nOriginal Cdf(50):\n  Expected: between 0.8 and 1\n  Got: %v\n", p)
+  }
+}
+
+func Benchmark_TDigest(b *testing.B) {
+  var digest TDigest
+  dist := Normal{ 0.0, 1.0 }
+  for n := 0; n <= b.N; n++ {
+    digest.Add(dist.Random())
+  }
+}
@@ -104,12 +104,14 @@ func testValues(examples []distributionTest) error {
 
 func testSamples(dist Distribution) error {
   // Generate samples.
-  samples := Sample(dist, numSamples)
-  if len(samples) != numSamples {
-    return fmt.Errorf("\nCould not generate samples.")
+  samples, err := Sample(dist, numSamples)
+  if err != nil || len(samples) != numSamples {
+    return fmt.Errorf("\nCould not generate samples: %v", err)
   }
+  var stats RunningStats
+  stats.AddAll(samples)
   // Test sample average against expected value if it exists.
-  sampleMean := averageFloats(samples)
+  sampleMean := stats.Mean()
   actualMean := dist.Mean()
   if !math.IsInf(actualMean,0) && !math.IsNaN(actualMean) {
     if !floatsEqual(actualMean, sampleMean, defaultEpsilon) {
@@ -117,7 +119,7 @@ func testSamples(dist Distribution) error {
     }
   }
   // Test sample variance against expected variance if it exists.
-  sampleVar := varianceFloats(samples, sampleMean)
+  sampleVar := stats.SampleVariance()
   actualVar := dist.Variance()
   if !math.IsInf(actualVar,0) && !math.IsNaN(actualVar) {
     if !floatsEqual(actualVar, sampleVar, defaultEpsilon * 10) {
@@ -176,25 +178,39 @@ func checkNaN(f1, f2 float64) bool {
   return false
 }
 
-func averageFloats(values []float64) float64 {
-  var total float64
-  for _, value := range values {
-    total += value
+func runBenchmark(b *testing.B, dist Distribution) {
+  for n := 0; n <= b.N; n++ {
+    dist.Random()
   }
-  return total / float64(len(values))
 }
 
-func varianceFloats(values []float64, mean float64) float64 {
-  var total, diff float64
-  for _, value := range values {
-    diff = value - mean
-    total += diff * diff
+const benchmarkPoints = 1000
+
+func benchmarkGrid(lo, hi float64) []float64 {
+  xs := make([]float64, benchmarkPoints)
+  for i := range xs {
+    xs[i] = lo + ((hi - lo) * (float64(i) + 0.5) / benchmarkPoints)
   }
-  return total / (float64(len(values)) - 1)
+  return xs
 }
 
-func runBenchmark(b *testing.B, dist Distribution) {
-  for n := 0; n <= b.N; n++ {
-    dist.Random()
+// Evaluates the density one point at a time over a grid on [lo, hi].
+func runPdfBenchmark(b *testing.B, dist Distribution, lo, hi float64) {
+  xs := benchmarkGrid(lo, hi)
+  b.ResetTimer()
+  for n := 0; n < b.N; n++ {
+    for _, x := range xs {
+      dist.Pdf(x)
+    }
+  }
+}
+
+// Evaluates the density with PdfSlice over the same grid as runPdfBenchmark.
+func runPdfSliceBenchmark(b *testing.B, dist Distribution, lo, hi float64) {
+  xs := benchmarkGrid(lo, hi)
+  out := make([]float64, len(xs))
+  b.ResetTimer()
+  for n := 0; n < b.N; n++ {
+    PdfSlice(dist, xs, out)
   }
 }
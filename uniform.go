@@ -23,8 +23,11 @@ func NewUniform(min float64, max float64) (Uniform, error) {
 }
 
 func (dist Uniform) Validate() error {
-  if dist.Max <= dist.Min {
-    return InvalidParamsError{ "Max must be greater than Min." }
+  if err := checkFinite("Uniform", "Min", dist.Min); err != nil {
+    return err
+  }
+  if err := checkParam("Uniform", "Max", dist.Max, dist.Max > dist.Min, "be greater than Min"); err != nil {
+    return err
   }
   return nil
 }
@@ -78,7 +81,19 @@ func (dist Uniform) Cdf(x float64) float64 {
   return result
 }
 
-func (dist Uniform) Random() float64 {
-  value := dist.Min + (rand.Float64() * (dist.Max - dist.Min))
+func (dist Uniform) Quantile(p float64) float64 {
+  if p < 0 || p > 1 || math.IsNaN(p) {
+    return math.NaN()
+  }
+  result := dist.Min + (p * (dist.Max - dist.Min))
+  return result
+}
+
+func (dist Uniform) RandomWith(r *rand.Rand) float64 {
+  value := dist.Min + (randFloat64(r) * (dist.Max - dist.Min))
   return value
 }
+
+func (dist Uniform) Random() float64 {
+  return dist.RandomWith(nil)
+}
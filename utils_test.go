@@ -9,6 +9,9 @@ type lowerIncGamma struct { s, x, out float64 }
 type nChoosek struct { n, k, out float64 }
 type betaFn struct { a, b, out float64 }
 type betaIncFn struct { x, a, b, out float64 }
+type polygammaFn struct { n int; x, out float64 }
+type besselFn struct { nu, x, i, k float64 }
+type zetaFn struct { s, a, out float64 }
 
 // Test at http://keisan.casio.com/exec/system/1180573447
 // Have to regularize it here.
@@ -30,6 +33,79 @@ func Test_Utils_GammaIncLower(t *testing.T) {
   }
 }
 
+// Test against the Poisson sum e⁻ᶻ Σ zᵏ/k! for whole s and erfc(√z) for
+// s = 1/2, comparing relative error so that tiny tails are checked too.
+func Test_Utils_GammaIncUpper(t *testing.T) {
+  examples := []lowerIncGamma{
+    lowerIncGamma{ 3,   50,  2.509303552201057e-19 },
+    lowerIncGamma{ 10,  30,  7.121750862815577e-06 },
+    lowerIncGamma{ 100, 80,  0.9828916869648674 },
+    lowerIncGamma{ 100, 130, 0.002750408367306527 },
+    lowerIncGamma{ 1,   700, 9.85967654375977e-305 },
+    lowerIncGamma{ 0.5, 40,  3.7440973842028806e-19 },
+    lowerIncGamma{ 0.5, 0.3, 0.4385780260809999 },
+  }
+  for _, example := range examples {
+    result := GammaIncUpper(example.s, example.x)
+    if math.Abs((result / example.out) - 1) > 1e-12 {
+      t.Fatalf("\nQ(%f, %f):\n  Expected: %g\n  Got: %g\n", example.s, example.x, example.out, result)
+    }
+    lower := GammaIncLower(example.s, example.x)
+    if !floatsPicoEqual(lower, 1 - example.out) {
+      t.Fatalf("\nP(%f, %f):\n  Expected: %g\n  Got: %g\n", example.s, example.x, 1 - example.out, lower)
+    }
+  }
+  if GammaIncUpper(2, 0) != 1 || GammaIncLower(2, math.Inf(1)) != 1 {
+    t.Fatal("\nExpected exact values at the ends of the range.")
+  }
+  if !math.IsNaN(GammaIncUpper(-1, 2)) || !math.IsNaN(GammaIncLower(2, -1)) {
+    t.Fatal("\nExpected NaN for invalid arguments.")
+  }
+}
+
+// Round trips through the forward functions, including far tails and shapes
+// below one where the starting approximations are weakest.
+func Test_Utils_GammaIncLowerInv(t *testing.T) {
+  examples := [][2]float64{
+    { 0.5, 0.01 }, { 0.5, 0.5 }, { 1, 0.3 }, { 2.5, 0.95 }, { 10, 1e-10 }, { 100, 0.999 }, { 0.1, 0.9 }, { 1000, 0.5 },
+  }
+  for _, example := range examples {
+    s, p := example[0], example[1]
+    x := GammaIncLowerInv(s, p)
+    if out := GammaIncLower(s, x); !floatsPicoEqual(out, p) {
+      t.Fatalf("\nP(%f, Inv(%f)):\n  Expected: %g\n  Got: %g\n", s, p, p, out)
+    }
+  }
+  // Exponential quantiles have a closed form.
+  if out := GammaIncLowerInv(1, 0.3); !floatsPicoEqual(out, -math.Log(0.7)) {
+    t.Fatalf("\n  Expected: %f\n  Got: %f\n", -math.Log(0.7), out)
+  }
+  if GammaIncLowerInv(2, 0) != 0 || !math.IsInf(GammaIncLowerInv(2, 1), 1) || !math.IsNaN(GammaIncLowerInv(2, 1.5)) {
+    t.Fatal("\nExpected exact values at the ends of the range.")
+  }
+}
+
+func Test_Utils_RegBetaIncInv(t *testing.T) {
+  examples := [][3]float64{
+    { 2, 3, 0.3 }, { 0.5, 0.5, 0.1 }, { 0.2, 5, 0.99 }, { 50, 40, 0.001 }, { 5, 0.5, 0.5 }, { 1, 1, 0.25 },
+  }
+  for _, example := range examples {
+    a, b, p := example[0], example[1], example[2]
+    x := RegBetaIncInv(a, b, p)
+    if out := RegBetaInc(a, b, x); !floatsPicoEqual(out, p) {
+      t.Fatalf("\nI(%f, %f, Inv(%f)):\n  Expected: %g\n  Got: %g\n", a, b, p, p, out)
+    }
+  }
+  // The arcsine distribution has quantile sin²(πp / 2).
+  expected := math.Pow(math.Sin(math.Pi * 0.1 / 2), 2)
+  if out := RegBetaIncInv(0.5, 0.5, 0.1); !floatsPicoEqual(out, expected) {
+    t.Fatalf("\n  Expected: %f\n  Got: %f\n", expected, out)
+  }
+  if RegBetaIncInv(2, 3, 0) != 0 || RegBetaIncInv(2, 3, 1) != 1 || !math.IsNaN(RegBetaIncInv(-2, 3, 0.5)) {
+    t.Fatal("\nExpected exact values at the ends of the range.")
+  }
+}
+
 func Test_Utils_BinomialCoefficient(t *testing.T) {
   examples := []nChoosek {
     nChoosek{ 10, 2,  45    },
@@ -97,3 +173,228 @@ func Test_Utils_RegBetaInc(t *testing.T) {
     }
   }
 }
+
+// Test against closed forms: ψ(1) = -γ, ψ(1/2) = -γ - 2ln2,
+// ψ⁽ⁿ⁾(1) = (-1)ⁿ⁺¹ n! ζ(n + 1) and ψ⁽ⁿ⁾(1/2) = (2ⁿ⁺¹ - 1) ψ⁽ⁿ⁾(1), with the
+// negative arguments from the recurrence and reflection formulas.
+func Test_Utils_Polygamma(t *testing.T) {
+  examples := []polygammaFn{
+    polygammaFn{ 0,  1,    -0.5772156649015329 },
+    polygammaFn{ 0,  0.5,  -1.9635100260214235 },
+    polygammaFn{ 0, -0.5,   0.03648997397857652 },
+    polygammaFn{ 0,  10,    2.251752589066721 },
+    polygammaFn{ 1,  1,     math.Pi * math.Pi / 6 },
+    polygammaFn{ 1,  0.5,   math.Pi * math.Pi / 2 },
+    polygammaFn{ 1, -0.5,   (math.Pi * math.Pi / 2) + 4 },
+    polygammaFn{ 1,  10,    0.10516633568168575 },
+    polygammaFn{ 2,  1,    -2.4041138063191885 },
+    polygammaFn{ 2,  0.5,  -16.828796644234320 },
+    polygammaFn{ 2, -0.5,  -0.8287966442343199 },
+    polygammaFn{ 3,  1,     math.Pi * math.Pi * math.Pi * math.Pi / 15 },
+    polygammaFn{ 3,  0.5,   math.Pi * math.Pi * math.Pi * math.Pi },
+    polygammaFn{ 4,  1,    -24.886266123440878 },
+    polygammaFn{ 15, 2.5,   564268.8820348989 },
+  }
+  for _, example := range examples {
+    result := Polygamma(example.n, example.x)
+    if !floatsPicoEqual(result / example.out, 1) {
+      t.Fatalf("\n  Expected: %v\n  Got: %v\n", example.out, result)
+    }
+    switch example.n {
+    case 0:
+      result = Digamma(example.x)
+    case 1:
+      result = Trigamma(example.x)
+    }
+    if !floatsPicoEqual(result / example.out, 1) {
+      t.Fatalf("\n  Expected: %v\n  Got: %v\n", example.out, result)
+    }
+  }
+  for _, x := range []float64{ 0, -1, -7, math.NaN() } {
+    if !math.IsNaN(Digamma(x)) || !math.IsNaN(Trigamma(x)) || !math.IsNaN(Polygamma(2, x)) {
+      t.Fatalf("\n  Expected NaN at %v\n", x)
+    }
+  }
+  if !math.IsNaN(Polygamma(-1, 1)) {
+    t.Fatal("\n  Expected NaN for a negative order.")
+  }
+}
+
+func Test_Utils_LogBeta(t *testing.T) {
+  examples := []betaFn {
+    betaFn{ 10, 2,  0.00909090909090909090909 },
+    betaFn{  8,  3, 0.0027777777777777777778  },
+    betaFn{  5,  5, 0.00158730158730158730159 },
+  }
+  for _, example := range examples {
+    result := LogBeta(example.a, example.b)
+    if !floatsPicoEqual(result, math.Log(example.out)) {
+      t.Fatalf("\n  Expected: %f\n  Got: %f\n", math.Log(example.out), result)
+    }
+  }
+  result := LogBeta(1000, 2000)
+  if !floatsNanoEqual(result, -1911.8746142144482) {
+    t.Fatalf("\n  Expected: %f\n  Got: %f\n", -1911.8746142144482, result)
+  }
+  if result := BetaFn(100, 100); !floatsPicoEqual(result / 2.2087606931994364e-61, 1) {
+    t.Fatalf("\n  Expected: %v\n  Got: %v\n", 2.2087606931994364e-61, result)
+  }
+  if !math.IsNaN(LogBeta(-1, 2)) {
+    t.Fatal("\n  Expected NaN for a negative argument.")
+  }
+}
+
+func Test_Utils_LogBinomialCoefficient(t *testing.T) {
+  examples := []nChoosek {
+    nChoosek{ 10, 2,  45    },
+    nChoosek{ 18, 13, 8568  },
+    nChoosek{ 20, 14, 38760 },
+    nChoosek{ 23, 0,  1     },
+  }
+  for _, example := range examples {
+    result := LogBinomialCoefficient(example.n, example.k)
+    if !floatsPicoEqual(result, math.Log(example.out)) {
+      t.Fatalf("\n  Expected: %f\n  Got: %f\n", math.Log(example.out), result)
+    }
+  }
+  result := LogBinomialCoefficient(1000, 500)
+  if !floatsNanoEqual(result, 689.467261567851) {
+    t.Fatalf("\n  Expected: %f\n  Got: %f\n", 689.467261567851, result)
+  }
+  if !math.IsNaN(LogBinomialCoefficient(3, 4)) || !math.IsNaN(LogBinomialCoefficient(3, -1)) {
+    t.Fatal("\n  Expected NaN for k outside [0, n].")
+  }
+}
+
+// Test against the power series for I and the trapezoid rule on
+// K_ν(x) = ∫ exp(-x cosh t) cosh νt dt, with large x compared scaled.
+func Test_Utils_Bessel(t *testing.T) {
+  examples := []besselFn{
+    besselFn{ 0,   1,    1.2660658777520082,  0.42102443824070834 },
+    besselFn{ 1,   1,    0.565159103992485,   0.6019072301972346 },
+    besselFn{ 0.3, 0.7,  0.8919002227528227,  0.689562489756975 },
+    besselFn{ 2.5, 3,    1.5153394466819652,  0.0840606319741174 },
+    besselFn{ 1.7, 12,   16710.733664102205,  2.4706289117108123e-06 },
+    besselFn{ 10,  5,    0.004580044419176053, 9.75856282917781 },
+    besselFn{ 0,   30,   781672297823.9775,   2.1324774964630566e-14 },
+    besselFn{ 4.2, 1.5,  0.010207250091329,   10.956879531336185 },
+    besselFn{ 0.5, 0.01, 0.07978978589453693, 12.40843453284693 },
+  }
+  for _, example := range examples {
+    if result := BesselI(example.nu, example.x); !floatsPicoEqual(result / example.i, 1) {
+      t.Fatalf("\n  Expected: %v\n  Got: %v\n", example.i, result)
+    }
+    if result := BesselK(example.nu, example.x); !floatsPicoEqual(result / example.k, 1) {
+      t.Fatalf("\n  Expected: %v\n  Got: %v\n", example.k, result)
+    }
+  }
+  scaled := []besselFn{
+    besselFn{ 0,   2000, 0.008921178276439672,  0.02802320501460432 },
+    besselFn{ 3,   5000, 0.0056369608425911434, 0.017740052715008132 },
+    besselFn{ 0.5, 5000, 0.005641895835477563,  0.01772453850905516 },
+  }
+  for _, example := range scaled {
+    if result := BesselIScaled(example.nu, example.x); !floatsPicoEqual(result / example.i, 1) {
+      t.Fatalf("\n  Expected: %v\n  Got: %v\n", example.i, result)
+    }
+    if result := BesselKScaled(example.nu, example.x); !floatsPicoEqual(result / example.k, 1) {
+      t.Fatalf("\n  Expected: %v\n  Got: %v\n", example.k, result)
+    }
+  }
+  if BesselI(0, 0) != 1 || BesselI(2, 0) != 0 || !math.IsInf(BesselK(0, 0), 1) || BesselI(-2, 3) != BesselI(2, 3) || BesselK(-1.5, 3) != BesselK(1.5, 3) {
+    t.Fatal("\n  Expected exact values at zero and for negative orders.")
+  }
+  if !math.IsInf(BesselI(1, 1000), 1) || BesselK(1, 1000) != 0 {
+    t.Fatal("\n  Expected the unscaled functions to overflow and underflow.")
+  }
+  if !math.IsNaN(BesselI(1, -1)) || !math.IsNaN(BesselI(-0.5, 1)) || !math.IsNaN(BesselK(1, -1)) {
+    t.Fatal("\n  Expected NaN outside the domain.")
+  }
+}
+
+// Test against closed forms and ζ(n + 1, x) = (-1)ⁿ⁺¹ ψ⁽ⁿ⁾(x) / n!.
+func Test_Utils_Zeta(t *testing.T) {
+  examples := []zetaFn{
+    zetaFn{ 2,    1,   math.Pi * math.Pi / 6 },
+    zetaFn{ 3,    1,   1.2020569031595942 },
+    zetaFn{ 4,    1,   math.Pi * math.Pi * math.Pi * math.Pi / 90 },
+    zetaFn{ 1.5,  1,   2.612375348685488 },
+    zetaFn{ 1.001, 1,  1000.5772884760117 },
+    zetaFn{ 2,    0.5, math.Pi * math.Pi / 2 },
+    zetaFn{ 3,    2,   0.2020569031595942 },
+    zetaFn{ 2,    10,  0.10516633568168575 },
+    zetaFn{ 16,   2.5, 564268.8820348989 / 1307674368000 },
+  }
+  for _, example := range examples {
+    result := HurwitzZeta(example.s, example.a)
+    if !floatsPicoEqual(result / example.out, 1) {
+      t.Fatalf("\n  Expected: %v\n  Got: %v\n", example.out, result)
+    }
+    if example.a == 1 && !floatsPicoEqual(Zeta(example.s) / example.out, 1) {
+      t.Fatalf("\n  Expected: %v\n  Got: %v\n", example.out, Zeta(example.s))
+    }
+  }
+  riemann := []zetaFn{
+    zetaFn{ 0.5,  1, -1.4603545088095868 },
+    zetaFn{ 0,    1, -0.5 },
+    zetaFn{ -0.5, 1, -0.2078862249773545 },
+    zetaFn{ -1,   1, -1.0 / 12 },
+    zetaFn{ -3,   1, 1.0 / 120 },
+    zetaFn{ -2,   1, 0 },
+  }
+  for _, example := range riemann {
+    result := Zeta(example.s)
+    if !floatsPicoEqual(result, example.out) {
+      t.Fatalf("\n  Expected: %v\n  Got: %v\n", example.out, result)
+    }
+  }
+  if !math.IsNaN(Zeta(1)) || !math.IsNaN(HurwitzZeta(0.5, 1)) || !math.IsNaN(HurwitzZeta(2, 0)) {
+    t.Fatal("\n  Expected NaN outside the domain.")
+  }
+}
+
+// Test against W₀(1) = Ω, W₀(-ln2 / 2) = -ln2 and W₋₁(-ln2 / 2) = -2ln2, and
+// check weʷ = x elsewhere.
+func Test_Utils_LambertW(t *testing.T) {
+  examples := []inOut{
+    inOut{ in: 1,             out: 0.5671432904097838 },
+    inOut{ in: math.E,        out: 1 },
+    inOut{ in: 10,            out: 1.7455280027406994 },
+    inOut{ in: -math.Ln2 / 2, out: -math.Ln2 },
+  }
+  for _, example := range examples {
+    result := LambertW0(example.in)
+    if !floatsPicoEqual(result, example.out) {
+      t.Fatalf("\n  Expected: %v\n  Got: %v\n", example.out, result)
+    }
+  }
+  examples = []inOut{
+    inOut{ in: -math.Ln2 / 2, out: -2 * math.Ln2 },
+    inOut{ in: -0.1,          out: -3.577152063957297 },
+  }
+  for _, example := range examples {
+    result := LambertWm1(example.in)
+    if !floatsPicoEqual(result, example.out) {
+      t.Fatalf("\n  Expected: %v\n  Got: %v\n", example.out, result)
+    }
+  }
+  for _, x := range []float64{ -0.36, -0.3, -1e-10, 1e-10, 0.5, 100, 1e300 } {
+    w := LambertW0(x)
+    if !(w >= -1) || !floatsPicoEqual(math.Log(math.Abs(w)) + w, math.Log(math.Abs(x))) {
+      t.Fatalf("\n  Expected weʷ = %v\n  Got: w = %v\n", x, w)
+    }
+    if x >= 0 {
+      continue
+    }
+    w = LambertWm1(x)
+    if !(w <= -1) || !floatsPicoEqual(math.Log(-w) + w, math.Log(-x)) {
+      t.Fatalf("\n  Expected weʷ = %v\n  Got: w = %v\n", x, w)
+    }
+  }
+  if LambertW0(-1 / math.E) != -1 || LambertWm1(-1 / math.E) != -1 || LambertW0(0) != 0 || !math.IsInf(LambertWm1(0), -1) {
+    t.Fatal("\n  Expected exact values at the ends of the range.")
+  }
+  if !math.IsNaN(LambertW0(-1)) || !math.IsNaN(LambertWm1(1)) {
+    t.Fatal("\n  Expected NaN outside the domain.")
+  }
+}
@@ -23,11 +23,11 @@ func NewWeibull(scale float64, shape float64) (Weibull, error) {
 }
 
 func (dist Weibull) Validate() error {
-  if dist.Scale <= 0 {
-    return InvalidParamsError{ "Scale must be greater than zero." }
+  if err := checkPositive("Weibull", "Scale", dist.Scale); err != nil {
+    return err
   }
-  if dist.Shape <= 0 {
-    return InvalidParamsError{ "Shape must be greater than zero." }
+  if err := checkPositive("Weibull", "Shape", dist.Shape); err != nil {
+    return err
   }
   return nil
 }
@@ -93,7 +93,19 @@ func (dist Weibull) Cdf(x float64) float64 {
   return result
 }
 
-func (dist Weibull) Random() float64 {
-  value := dist.Scale * math.Pow(-math.Log(rand.Float64()), 1 / dist.Shape)
+func (dist Weibull) Quantile(p float64) float64 {
+  if p < 0 || p > 1 || math.IsNaN(p) {
+    return math.NaN()
+  }
+  result := dist.Scale * math.Pow(-math.Log1p(-p), 1 / dist.Shape)
+  return result
+}
+
+func (dist Weibull) RandomWith(r *rand.Rand) float64 {
+  value := dist.Scale * math.Pow(-math.Log(randFloat64(r)), 1 / dist.Shape)
   return value
 }
+
+func (dist Weibull) Random() float64 {
+  return dist.RandomWith(nil)
+}